@@ -12,6 +12,7 @@ func main() {
 
 	validateMap()
 	validateNestedMap()
+	validateMapErr()
 }
 
 func validateMap() {
@@ -85,3 +86,33 @@ func validateNestedMap() {
 
 	// Data is invalid
 }
+
+func validateMapErr() {
+
+	data := map[string]interface{}{
+		"name": "Arshiya Kiani",
+		"phones": []interface{}{
+			map[string]interface{}{"number": "11-111-1111"},
+			map[string]interface{}{"number": ""},
+		},
+		"nickname": "Ari",
+	}
+
+	rules := map[string]interface{}{
+		"name": "required,min=4,max=32",
+		"phones": map[string]interface{}{
+			"number": "required,min=4,max=32",
+		},
+	}
+
+	// ValidateMapErr returns ValidationErrors with namespaces built from the keys of the data
+	// eg. 'phones[1].number', and when using WithStrictMaps also reports the keys which have
+	// no rules eg. 'nickname'
+	err := validator.New(validator.WithStrictMaps()).ValidateMapErr(data, rules)
+	if err != nil {
+		for _, e := range err.(validator.ValidationErrors) {
+			fmt.Println(e.Namespace(), e.Tag())
+		}
+		// Data is invalid
+	}
+}
@@ -0,0 +1,301 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const defaultAsyncWorkers = 8
+
+// asyncValidation is a validation registered using RegisterAsyncValidation.
+type asyncValidation struct {
+	fn      FuncErr
+	batch   func(ctx context.Context, fls []FieldLevel) ([]error, error) // validates all of a call's fields at once, when set
+	timeout time.Duration
+}
+
+// asyncJob is an asynchronous validation scheduled while validating, which is
+// run once all of the synchronous validations have been, see runAsync.
+type asyncJob struct {
+	async     *asyncValidation
+	fl        *validate // snapshot of the FieldLevel at the time the validation was scheduled
+	fe        *fieldError
+	sensitive bool
+	allErrors bool
+	pos       int // the number of errors reported before the validation was scheduled
+	err       error
+	abort     error // failure of a batched validation itself, such as of a LookupProvider, see validate.abort
+	panicked  interface{}
+}
+
+// RegisterAsyncValidation adds a validation with the given tag which performs
+// I/O, such as checking that a username is unique within a datastore, so that
+// instead of running when it's encountered it's scheduled and run concurrently
+// with the other asynchronous validations of the call, once all the synchronous
+// validations have run, using a pool of workers bounded by WithAsyncWorkers.
+//
+// The ctx passed to the function is derived from the one passed to StructCtx,
+// or the other context aware methods, and is cancelled after timeout when it's
+// greater than zero. A returned error fails the validation the same as
+// RegisterValidationErr, including the error of the ctx when the validation
+// times out, which is available using errors.Is.
+//
+//	validate.RegisterAsyncValidation("unique_username", func(ctx context.Context, fl validator.FieldLevel) error {
+//	    taken, err := users.Exists(ctx, fl.Field().String())
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if taken {
+//	        return errors.New("username is taken")
+//	    }
+//	    return nil
+//	}, time.Second)
+//
+// The errors are merged into the ValidationErrors in the order they would have
+// been reported in had they run synchronously. Asynchronous validations aren't
+// run for fields which failed a synchronous validation, and their errors aren't
+// visible to struct level validations eg. using StructLevelFailures. When
+// used within an 'or' tag they run synchronously.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterAsyncValidation(tag string, fn FuncErr, timeout time.Duration) error {
+
+	if fn == nil {
+		return errors.New("function cannot be empty")
+	}
+
+	return v.registerAsyncValidation(tag, &asyncValidation{fn: fn, timeout: timeout})
+}
+
+// registerAsyncValidation registers the asynchronous validation, along with its synchronous fallback.
+func (v *Validate) registerAsyncValidation(tag string, async *asyncValidation) error {
+
+	// used when the validation can't be scheduled eg. within an 'or' tag
+	syncFn := func(ctx context.Context, fl FieldLevel) error {
+		ctx, cancel := async.context(ctx)
+		defer cancel()
+
+		if async.batch != nil {
+			errs, err := async.batch(ctx, []FieldLevel{fl})
+			if err != nil {
+				fl.(*validate).abort = err
+				return nil
+			}
+			return errs[0]
+		}
+
+		return async.fn(ctx, fl)
+	}
+
+	if err := v.registerValidation(tag, wrapFuncErr(syncFn), false, false); err != nil {
+		return err
+	}
+
+	if v.asyncValidations == nil {
+		v.asyncValidations = make(map[string]*asyncValidation)
+	}
+	v.asyncValidations[tag] = async
+
+	return nil
+}
+
+// context returns the ctx the validation is run with.
+func (a *asyncValidation) context(ctx context.Context) (context.Context, context.CancelFunc) {
+
+	if a.timeout > 0 {
+		return context.WithTimeout(ctx, a.timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// scheduleAsync schedules the asynchronous validation of the current field, fe
+// being the error reported if it fails.
+func (v *validate) scheduleAsync(parent reflect.Value, current reflect.Value, cf *cField, ct *cTag, fe *fieldError) {
+
+	// the cField is reused when diving
+	fcf := *cf
+
+	v.setPath(fe, cf)
+
+	v.async = append(v.async, &asyncJob{
+		async: ct.async,
+		fl: &validate{
+			v:          v.v,
+			top:        v.top,
+			slflParent: parent,
+			flField:    current,
+			cf:         &fcf,
+			ct:         ct,
+			clock:      v.clock,
+			misc:       make([]byte, 32),
+		},
+		fe:        fe,
+		sensitive: ct.sensitive,
+		allErrors: ct.allErrors,
+		pos:       len(v.errs),
+	})
+}
+
+// runAsync runs the asynchronous validations scheduled during the call and
+// returns the failure of a batched validation itself, such as of a
+// LookupProvider, which is returned by the call instead of its errors.
+func (v *validate) runAsync(ctx context.Context) error {
+
+	if len(v.async) > 0 {
+		v.runScheduled(ctx)
+	}
+
+	err := v.abort
+	v.abort = nil
+
+	return err
+}
+
+// runScheduled runs the scheduled asynchronous validations, using up to the
+// number of workers set using WithAsyncWorkers, and merges their errors into
+// those of the synchronous validations.
+func (v *validate) runScheduled(ctx context.Context) {
+
+	jobs := v.async
+	v.async = nil
+
+	// there's no need to run the validations of fields which have already failed, unless using the 'allerrors' tag
+	failed := make(map[string]struct{}, len(v.errs))
+	for _, fe := range v.errs {
+		failed[fe.StructNamespace()] = struct{}{}
+	}
+
+	// the jobs of batched validations are run together
+	var units [][]*asyncJob
+	batches := make(map[*asyncValidation]int)
+
+	for _, job := range jobs {
+
+		if _, ok := failed[job.fe.structNs]; ok && !job.allErrors {
+			continue
+		}
+
+		if job.async.batch == nil {
+			units = append(units, []*asyncJob{job})
+			continue
+		}
+
+		idx, ok := batches[job.async]
+		if !ok {
+			idx = len(units)
+			batches[job.async] = idx
+			units = append(units, nil)
+		}
+
+		units[idx] = append(units[idx], job)
+	}
+
+	pending := make(chan []*asyncJob, len(units))
+	for _, unit := range units {
+		pending <- unit
+	}
+	close(pending)
+
+	workers := v.v.asyncWorkers
+	if workers <= 0 {
+		workers = defaultAsyncWorkers
+	}
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for unit := range pending {
+				runAsyncJobs(ctx, unit)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	var errs ValidationErrors
+	i := 0
+
+	for _, job := range jobs {
+
+		if job.panicked != nil {
+			panic(job.panicked)
+		}
+
+		if job.abort != nil && v.abort == nil {
+			v.abort = job.abort
+		}
+
+		if job.err == nil {
+			continue
+		}
+
+		job.fe.err = job.err
+		v.redact(job.fe, job.sensitive)
+
+		if job.fe.severity == SeverityWarning {
+			v.warns = append(v.warns, job.fe)
+			continue
+		}
+
+		// only the first failed validation of a field is reported, unless using the 'allerrors' tag
+		if _, ok := failed[job.fe.structNs]; ok && !job.allErrors {
+			continue
+		}
+		failed[job.fe.structNs] = struct{}{}
+
+		errs = append(errs, v.errs[i:job.pos]...)
+		errs = append(errs, job.fe)
+		i = job.pos
+	}
+
+	if errs != nil {
+		v.errs = append(errs, v.errs[i:]...)
+	}
+}
+
+// runAsyncJobs runs the asynchronous validation of the jobs, which are either a single job or those of a batched
+// validation, recovering any panic so that it can be raised by the goroutine of the call.
+func runAsyncJobs(ctx context.Context, jobs []*asyncJob) {
+
+	async := jobs[0].async
+
+	defer func() {
+		if r := recover(); r != nil {
+			jobs[0].panicked = r
+		}
+	}()
+
+	ctx, cancel := async.context(ctx)
+	defer cancel()
+
+	if async.batch == nil {
+		jobs[0].err = async.fn(ctx, jobs[0].fl)
+		return
+	}
+
+	fls := make([]FieldLevel, len(jobs))
+	for i := 0; i < len(jobs); i++ {
+		fls[i] = jobs[i].fl
+	}
+
+	errs, err := async.batch(ctx, fls)
+	if err != nil {
+		jobs[0].abort = err
+		return
+	}
+
+	for i := 0; i < len(jobs); i++ {
+		jobs[i].err = errs[i]
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/fs"
 	"net"
+	"net/netip"
 	"net/url"
 	"os"
 	"reflect"
@@ -34,6 +35,12 @@ type Func func(fl FieldLevel) bool
 // validation needs. The return value should be true when validation succeeds.
 type FuncCtx func(ctx context.Context, fl FieldLevel) bool
 
+// FuncErr accepts a context.Context and FieldLevel interface for all
+// validation needs. The return value should be nil when validation succeeds,
+// otherwise the returned error is wrapped into the resulting FieldError and
+// can be retrieved using errors.As or FieldErrorDetails.
+type FuncErr func(ctx context.Context, fl FieldLevel) error
+
 // wrapFunc wraps normal Func makes it compatible with FuncCtx
 func wrapFunc(fn Func) FuncCtx {
 	if fn == nil {
@@ -44,6 +51,22 @@ func wrapFunc(fn Func) FuncCtx {
 	}
 }
 
+// wrapFuncErr wraps a FuncErr makes it compatible with FuncCtx, the returned
+// error is kept so it can be attached to the fieldError created on failure.
+func wrapFuncErr(fn FuncErr) FuncCtx {
+	if fn == nil {
+		return nil // be sure not to wrap a bad function.
+	}
+	return func(ctx context.Context, fl FieldLevel) bool {
+		err := fn(ctx, fl)
+		if err == nil {
+			return true
+		}
+		fl.(*validate).fnErr = err
+		return false
+	}
+}
+
 var (
 	restrictedTags = map[string]struct{}{
 		diveTag:           {},
@@ -52,14 +75,43 @@ var (
 		structOnlyTag:     {},
 		omitempty:         {},
 		omitnil:           {},
+		omitzero:          {},
+		splitTag:          {},
+		splitTrimTag:      {},
+		splitWSTag:        {},
 		skipValidationTag: {},
 		utf8HexComma:      {},
 		utf8Pipe:          {},
 		noStructLevelTag:  {},
+		allErrorsTag:      {},
+		sensitiveTag:      {},
+		noTypeRulesTag:    {},
 		requiredTag:       {},
 		isdefault:         {},
 	}
 
+	// bakedInSensitiveTags are the baked in validations which are marked as
+	// sensitive when using the WithSensitiveTagRedaction option.
+	bakedInSensitiveTags = []string{
+		"credit_card",
+		"luhn_checksum",
+		"jwt",
+		"ssn",
+	}
+
+	// requiredTags are the tags which, when failing, always stop the remaining
+	// validations on a field from running even if all field errors are reported
+	// as there is no value to validate.
+	requiredTags = map[string]struct{}{
+		requiredTag:           {},
+		requiredIfTag:         {},
+		requiredUnlessTag:     {},
+		requiredWithTag:       {},
+		requiredWithAllTag:    {},
+		requiredWithoutTag:    {},
+		requiredWithoutAllTag: {},
+	}
+
 	// bakedInAliases is a default mapping of a single validation tag that
 	// defines a common or complex set of validation(s) to simplify
 	// adding validation to structs.
@@ -99,6 +151,10 @@ var (
 		"lte":                           isLte,
 		"gt":                            isGt,
 		"gte":                           isGte,
+		"before":                        isBefore,
+		"after":                         isAfter,
+		"within":                        isWithin,
+		"not_older_than":                isNotOlderThan,
 		"eqfield":                       isEqField,
 		"eqcsfield":                     isEqCrossStructField,
 		"necsfield":                     isNeCrossStructField,
@@ -189,6 +245,11 @@ var (
 		"cidrv4":                        isCIDRv4,
 		"cidrv6":                        isCIDRv6,
 		"cidr":                          isCIDR,
+		"ip_in":                         isIPIn,
+		"public_ip":                     isPublicIP,
+		"private_ip":                    isPrivateIP,
+		"loopback_ip":                   isLoopbackIP,
+		"multicast_ip":                  isMulticastIP,
 		"tcp4_addr":                     isTCP4AddrResolvable,
 		"tcp6_addr":                     isTCP6AddrResolvable,
 		"tcp_addr":                      isTCPAddrResolvable,
@@ -400,46 +461,113 @@ func isMAC(fl FieldLevel) bool {
 
 // isCIDRv4 is the validation function for validating if the field's value is a valid v4 CIDR address.
 func isCIDRv4(fl FieldLevel) bool {
-	ip, net, err := net.ParseCIDR(fl.Field().String())
+	val, _ := netString(fl.Field())
+	ip, net, err := net.ParseCIDR(val)
 
 	return err == nil && ip.To4() != nil && net.IP.Equal(ip)
 }
 
 // isCIDRv6 is the validation function for validating if the field's value is a valid v6 CIDR address.
 func isCIDRv6(fl FieldLevel) bool {
-	ip, _, err := net.ParseCIDR(fl.Field().String())
+	val, _ := netString(fl.Field())
+	ip, _, err := net.ParseCIDR(val)
 
 	return err == nil && ip.To4() == nil
 }
 
 // isCIDR is the validation function for validating if the field's value is a valid v4 or v6 CIDR address.
 func isCIDR(fl FieldLevel) bool {
-	_, _, err := net.ParseCIDR(fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, _, err := net.ParseCIDR(val)
 
 	return err == nil
 }
 
 // isIPv4 is the validation function for validating if a value is a valid v4 IP address.
 func isIPv4(fl FieldLevel) bool {
-	ip := net.ParseIP(fl.Field().String())
+	val, _ := netString(fl.Field())
+	ip := net.ParseIP(val)
 
 	return ip != nil && ip.To4() != nil
 }
 
 // isIPv6 is the validation function for validating if the field's value is a valid v6 IP address.
 func isIPv6(fl FieldLevel) bool {
-	ip := net.ParseIP(fl.Field().String())
+	val, _ := netString(fl.Field())
+	ip := net.ParseIP(val)
 
 	return ip != nil && ip.To4() == nil
 }
 
 // isIP is the validation function for validating if the field's value is a valid v4 or v6 IP address.
 func isIP(fl FieldLevel) bool {
-	ip := net.ParseIP(fl.Field().String())
+	val, _ := netString(fl.Field())
+	ip := net.ParseIP(val)
 
 	return ip != nil
 }
 
+// isIPIn is the validation function for validating if the field's value is an IP address within one of the space
+// separated prefixes of the param, or equal to one of its addresses, eg. ip_in=10.0.0.0/8 192.168.0.0/16
+func isIPIn(fl FieldLevel) bool {
+	addr, ok := netipAddr(fl.Field())
+	if !ok {
+		return false
+	}
+
+	for _, param := range parseOneOfParam2(fl.Param()) {
+
+		if !strings.Contains(param, "/") {
+			ip, err := netip.ParseAddr(param)
+			panicIf(err)
+
+			if ip.Unmap() == addr {
+				return true
+			}
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(param)
+		panicIf(err)
+
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPublicIP is the validation function for validating if the field's value is a global unicast IP address which
+// isn't private, excluding loopback, link-local, multicast and unspecified addresses.
+func isPublicIP(fl FieldLevel) bool {
+	addr, ok := netipAddr(fl.Field())
+
+	return ok && addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+// isPrivateIP is the validation function for validating if the field's value is a private IP address as per RFC 1918
+// for IPv4 and RFC 4193 for IPv6.
+func isPrivateIP(fl FieldLevel) bool {
+	addr, ok := netipAddr(fl.Field())
+
+	return ok && addr.IsPrivate()
+}
+
+// isLoopbackIP is the validation function for validating if the field's value is a loopback IP address.
+func isLoopbackIP(fl FieldLevel) bool {
+	addr, ok := netipAddr(fl.Field())
+
+	return ok && addr.IsLoopback()
+}
+
+// isMulticastIP is the validation function for validating if the field's value is a multicast IP address.
+func isMulticastIP(fl FieldLevel) bool {
+	addr, ok := netipAddr(fl.Field())
+
+	return ok && addr.IsMulticast()
+}
+
 // isSSN is the validation function for validating if the field's value is a valid SSN.
 func isSSN(fl FieldLevel) bool {
 	field := fl.Field()
@@ -974,6 +1102,10 @@ func isNeField(fl FieldLevel) bool {
 		if fieldType != currentField.Type() {
 			return true
 		}
+
+		if c, ok := compareFields(field, currentField); ok {
+			return c != 0
+		}
 	}
 
 	// default reflect.String:
@@ -1031,6 +1163,10 @@ func isLteCrossStructField(fl FieldLevel) bool {
 		if fieldType != topField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, topField); ok {
+			return c <= 0
+		}
 	}
 
 	// default reflect.String:
@@ -1078,6 +1214,10 @@ func isLtCrossStructField(fl FieldLevel) bool {
 		if fieldType != topField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, topField); ok {
+			return c < 0
+		}
 	}
 
 	// default reflect.String:
@@ -1124,6 +1264,10 @@ func isGteCrossStructField(fl FieldLevel) bool {
 		if fieldType != topField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, topField); ok {
+			return c >= 0
+		}
 	}
 
 	// default reflect.String:
@@ -1170,6 +1314,10 @@ func isGtCrossStructField(fl FieldLevel) bool {
 		if fieldType != topField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, topField); ok {
+			return c > 0
+		}
 	}
 
 	// default reflect.String:
@@ -1219,6 +1367,10 @@ func isNeCrossStructField(fl FieldLevel) bool {
 		if fieldType != topField.Type() {
 			return true
 		}
+
+		if c, ok := compareFields(field, topField); ok {
+			return c != 0
+		}
 	}
 
 	// default reflect.String:
@@ -1268,6 +1420,10 @@ func isEqCrossStructField(fl FieldLevel) bool {
 		if fieldType != topField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, topField); ok {
+			return c == 0
+		}
 	}
 
 	// default reflect.String:
@@ -1317,6 +1473,10 @@ func isEqField(fl FieldLevel) bool {
 		if fieldType != currentField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, currentField); ok {
+			return c == 0
+		}
 	}
 
 	// default reflect.String:
@@ -1364,6 +1524,10 @@ func isEq(fl FieldLevel) bool {
 		return field.Bool() == p
 	}
 
+	if c, ok := compareParam(field, param); ok {
+		return c == 0
+	}
+
 	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 }
 
@@ -1449,10 +1613,7 @@ func isBase64RawURL(fl FieldLevel) bool {
 func isURI(fl FieldLevel) bool {
 	field := fl.Field()
 
-	switch field.Kind() {
-	case reflect.String:
-
-		s := field.String()
+	if s, ok := netString(field); ok {
 
 		// checks needed as of Go 1.6 because of change https://github.com/golang/go/commit/617c93ce740c3c3cc28cdd1a0d712be183d0b328#diff-6c2d018290e298803c0c9419d8739885L195
 		// emulate browser and strip the '#' suffix prior to validation. see issue-#237
@@ -1485,10 +1646,9 @@ func isFileURL(path string) bool {
 func isURL(fl FieldLevel) bool {
 	field := fl.Field()
 
-	switch field.Kind() {
-	case reflect.String:
+	if str, ok := netString(field); ok {
 
-		s := strings.ToLower(field.String())
+		s := strings.ToLower(str)
 
 		if len(s) == 0 {
 			return false
@@ -1520,10 +1680,9 @@ func isHttpURL(fl FieldLevel) bool {
 	}
 
 	field := fl.Field()
-	switch field.Kind() {
-	case reflect.String:
+	if str, ok := netString(field); ok {
 
-		s := strings.ToLower(field.String())
+		s := strings.ToLower(str)
 
 		url, err := url.Parse(s)
 		if err != nil || url.Host == "" {
@@ -1792,7 +1951,7 @@ func hasValue(fl FieldLevel) bool {
 		if fl.(*validate).fldIsPointer && field.Interface() != nil {
 			return true
 		}
-		return field.IsValid() && !field.IsZero()
+		return field.IsValid() && !isZero(field)
 	}
 }
 
@@ -1816,7 +1975,7 @@ func requireCheckFieldKind(fl FieldLevel, param string, defaultNotFoundValue boo
 		if nullable && field.Interface() != nil {
 			return false
 		}
-		return field.IsValid() && field.IsZero()
+		return field.IsValid() && isZero(field)
 	}
 }
 
@@ -2068,6 +2227,10 @@ func isGteField(fl FieldLevel) bool {
 		if fieldType != currentField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, currentField); ok {
+			return c >= 0
+		}
 	}
 
 	// default reflect.String
@@ -2114,6 +2277,10 @@ func isGtField(fl FieldLevel) bool {
 		if fieldType != currentField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, currentField); ok {
+			return c > 0
+		}
 	}
 
 	// default reflect.String
@@ -2161,16 +2328,64 @@ func isGte(fl FieldLevel) bool {
 
 		if field.Type().ConvertibleTo(timeType) {
 
-			now := time.Now().UTC()
+			now := currentTime(fl)
 			t := field.Convert(timeType).Interface().(time.Time)
 
 			return t.After(now) || t.Equal(now)
 		}
 	}
 
+	if c, ok := compareParam(field, param); ok {
+		return c >= 0
+	}
+
 	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 }
 
+// isBefore is the validation function for validating if the current field's time is before the param's time, either
+// now, relative to now eg. now+1h or a RFC 3339 date-time or date, optionally followed by the layout of string fields.
+func isBefore(fl FieldLevel) bool {
+	param, layout := TimeParam(fl.Param())
+	t, ok := fieldTime(fl.Field(), layout)
+
+	return ok && t.Before(asTime(param, layout, currentTime(fl)))
+}
+
+// isAfter is the validation function for validating if the current field's time is after the param's time, either
+// now, relative to now eg. now-1h or a RFC 3339 date-time or date, optionally followed by the layout of string fields.
+func isAfter(fl FieldLevel) bool {
+	param, layout := TimeParam(fl.Param())
+	t, ok := fieldTime(fl.Field(), layout)
+
+	return ok && t.After(asTime(param, layout, currentTime(fl)))
+}
+
+// isWithin is the validation function for validating if the current field's time is within the param's duration of
+// now, either before or after it, optionally followed by the layout of string fields.
+func isWithin(fl FieldLevel) bool {
+	param, layout := TimeParam(fl.Param())
+	t, ok := fieldTime(fl.Field(), layout)
+	if !ok {
+		return false
+	}
+
+	d := t.Sub(currentTime(fl))
+	if d < 0 {
+		d = -d
+	}
+
+	return d <= asDuration(param)
+}
+
+// isNotOlderThan is the validation function for validating if the current field's time is no more than the param's
+// duration before now, optionally followed by the layout of string fields.
+func isNotOlderThan(fl FieldLevel) bool {
+	param, layout := TimeParam(fl.Param())
+	t, ok := fieldTime(fl.Field(), layout)
+
+	return ok && !t.Before(currentTime(fl).Add(-asDuration(param)))
+}
+
 // isGt is the validation function for validating if the current field's value is greater than the param's value.
 func isGt(fl FieldLevel) bool {
 	field := fl.Field()
@@ -2212,10 +2427,14 @@ func isGt(fl FieldLevel) bool {
 
 		if field.Type().ConvertibleTo(timeType) {
 
-			return field.Convert(timeType).Interface().(time.Time).After(time.Now().UTC())
+			return field.Convert(timeType).Interface().(time.Time).After(currentTime(fl))
 		}
 	}
 
+	if c, ok := compareParam(field, param); ok {
+		return c > 0
+	}
+
 	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 }
 
@@ -2257,6 +2476,10 @@ func hasLengthOf(fl FieldLevel) bool {
 		return field.Float() == p
 	}
 
+	if n, ok := lengthOf(field); ok {
+		return n == asInt(param)
+	}
+
 	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 }
 
@@ -2305,6 +2528,10 @@ func isLteField(fl FieldLevel) bool {
 		if fieldType != currentField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, currentField); ok {
+			return c <= 0
+		}
 	}
 
 	// default reflect.String
@@ -2351,6 +2578,10 @@ func isLtField(fl FieldLevel) bool {
 		if fieldType != currentField.Type() {
 			return false
 		}
+
+		if c, ok := compareFields(field, currentField); ok {
+			return c < 0
+		}
 	}
 
 	// default reflect.String
@@ -2398,13 +2629,17 @@ func isLte(fl FieldLevel) bool {
 
 		if field.Type().ConvertibleTo(timeType) {
 
-			now := time.Now().UTC()
+			now := currentTime(fl)
 			t := field.Convert(timeType).Interface().(time.Time)
 
 			return t.Before(now) || t.Equal(now)
 		}
 	}
 
+	if c, ok := compareParam(field, param); ok {
+		return c <= 0
+	}
+
 	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 }
 
@@ -2449,10 +2684,14 @@ func isLt(fl FieldLevel) bool {
 
 		if field.Type().ConvertibleTo(timeType) {
 
-			return field.Convert(timeType).Interface().(time.Time).Before(time.Now().UTC())
+			return field.Convert(timeType).Interface().(time.Time).Before(currentTime(fl))
 		}
 	}
 
+	if c, ok := compareParam(field, param); ok {
+		return c < 0
+	}
+
 	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 }
 
@@ -2467,7 +2706,8 @@ func isTCP4AddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveTCPAddr("tcp4", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveTCPAddr("tcp4", val)
 	return err == nil
 }
 
@@ -2477,7 +2717,8 @@ func isTCP6AddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveTCPAddr("tcp6", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveTCPAddr("tcp6", val)
 
 	return err == nil
 }
@@ -2488,7 +2729,8 @@ func isTCPAddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveTCPAddr("tcp", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveTCPAddr("tcp", val)
 
 	return err == nil
 }
@@ -2499,7 +2741,8 @@ func isUDP4AddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveUDPAddr("udp4", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveUDPAddr("udp4", val)
 
 	return err == nil
 }
@@ -2510,7 +2753,8 @@ func isUDP6AddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveUDPAddr("udp6", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveUDPAddr("udp6", val)
 
 	return err == nil
 }
@@ -2521,7 +2765,8 @@ func isUDPAddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveUDPAddr("udp", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveUDPAddr("udp", val)
 
 	return err == nil
 }
@@ -2532,7 +2777,8 @@ func isIP4AddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveIPAddr("ip4", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveIPAddr("ip4", val)
 
 	return err == nil
 }
@@ -2543,7 +2789,8 @@ func isIP6AddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveIPAddr("ip6", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveIPAddr("ip6", val)
 
 	return err == nil
 }
@@ -2554,7 +2801,8 @@ func isIPAddrResolvable(fl FieldLevel) bool {
 		return false
 	}
 
-	_, err := net.ResolveIPAddr("ip", fl.Field().String())
+	val, _ := netString(fl.Field())
+	_, err := net.ResolveIPAddr("ip", val)
 
 	return err == nil
 }
@@ -2567,7 +2815,7 @@ func isUnixAddrResolvable(fl FieldLevel) bool {
 }
 
 func isIP4Addr(fl FieldLevel) bool {
-	val := fl.Field().String()
+	val, _ := netString(fl.Field())
 
 	if idx := strings.LastIndex(val, ":"); idx != -1 {
 		val = val[0:idx]
@@ -2579,7 +2827,7 @@ func isIP4Addr(fl FieldLevel) bool {
 }
 
 func isIP6Addr(fl FieldLevel) bool {
-	val := fl.Field().String()
+	val, _ := netString(fl.Field())
 
 	if idx := strings.LastIndex(val, ":"); idx != -1 {
 		if idx != 0 && val[idx-1:idx] == "]" {
@@ -2711,7 +2959,11 @@ func isJWT(fl FieldLevel) bool {
 
 // isHostnamePort validates a <dns>:<port> combination for fields typically used for socket address.
 func isHostnamePort(fl FieldLevel) bool {
-	val := fl.Field().String()
+	if ap, ok := getValue(fl.Field()).(netip.AddrPort); ok {
+		return ap.IsValid() && ap.Port() != 0
+	}
+
+	val, _ := netString(fl.Field())
 	host, port, err := net.SplitHostPort(val)
 	if err != nil {
 		return false
@@ -3,6 +3,7 @@ package validator
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -21,12 +22,22 @@ const (
 	typeKeys
 	typeEndKeys
 	typeOmitNil
+	typeOmitZero
+	typeSplit
 )
 
 const (
 	invalidValidation   = "Invalid validation tag on field '%s'"
 	undefinedValidation = "Undefined validation function '%s' on field '%s'"
 	keysTagNotDefined   = "'" + endKeysTag + "' tag encountered without a corresponding '" + keysTag + "' tag"
+	invalidRulePath     = "Invalid rule path '%s' on type '%s': %s"
+	rulePathElem        = "[*]"
+	invalidValueType    = "Validation '%s' on field '%s' requires type '%s', got '%s'"
+	invalidSplitParam   = "'%s' tag on field '%s' requires a separator eg. split=0x2C"
+	invalidSplitType    = "'%s' tag on field '%s' requires a string, got '%s'"
+	invalidModifier     = "Invalid modifier tag on field '%s'"
+	undefinedModifier   = "Undefined modifier '%s' on field '%s'"
+	invalidMapRule      = "Invalid rule for key '%s', must be a string of tags or a map[string]interface{} of rules, got '%T'"
 )
 
 type structCache struct {
@@ -69,6 +80,36 @@ func (tc *tagCache) Set(key string, value *cTag) {
 	tc.m.Store(nm)
 }
 
+type customTypeCache struct {
+	lock sync.Mutex
+	m    atomic.Value // map[reflect.Type]customTypeMatch
+}
+
+// customTypeMatch is the CustomTypeFunc registered against an interface which a type implements, addr being true
+// when only a pointer to the type implements it.
+type customTypeMatch struct {
+	fn   CustomTypeFunc
+	addr bool
+}
+
+func (cc *customTypeCache) Get(key reflect.Type) (c customTypeMatch, found bool) {
+	c, found = cc.m.Load().(map[reflect.Type]customTypeMatch)[key]
+	return
+}
+
+func (cc *customTypeCache) Set(key reflect.Type, value customTypeMatch) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	m := cc.m.Load().(map[reflect.Type]customTypeMatch)
+	nm := make(map[reflect.Type]customTypeMatch, len(m)+1)
+	for k, v := range m {
+		nm[k] = v
+	}
+	nm[key] = value
+	cc.m.Store(nm)
+}
+
 type cStruct struct {
 	name   string
 	fields []*cField
@@ -79,8 +120,12 @@ type cField struct {
 	idx        int
 	name       string
 	altName    string
+	altNames   []string // names under each of the naming schemes, see RegisterTagNameFuncFor
 	namesEqual bool
-	cTags      *cTag
+	inline     bool     // embedded struct whose fields are promoted into the parent's namespace, see WithJSONFieldNames
+	nested     *cStruct // used instead of the cached cStruct for the field's struct value, or that of its elements
+	cTags      *cTag    // nil when the field is only kept for its modifiers
+	modTags    *cTag    // used instead of cTags when normalizing, nil when the field isn't normalized, see Normalize
 }
 
 type cTag struct {
@@ -91,12 +136,17 @@ type cTag struct {
 	keys                 *cTag // only populated when using tag's 'keys' and 'endkeys' for map key validation
 	next                 *cTag
 	fn                   FuncCtx
+	mod                  ModifierFunc     // used instead of fn when normalizing, see RegisterModifier
+	async                *asyncValidation // scheduled instead of calling fn, see RegisterAsyncValidation
 	typeof               tagType
 	hasTag               bool
 	hasAlias             bool
 	hasParam             bool // true if parameter used eg. eq= where the equal sign has been set
 	isBlockEnd           bool // indicates the current tag represents the last validation in the block
 	runValidationWhenNil bool
+	allErrors            bool // continue with the remaining validations on failure, set using the 'allerrors' tag
+	sensitive            bool // the value must not be retained on failure, see WithValueRedactor
+	severity             Severity
 }
 
 func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStruct {
@@ -112,15 +162,26 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 		return cs
 	}
 
-	cs = &cStruct{name: sName, fields: make([]*cField, 0), fn: v.structLevelFuncs[typ]}
+	cs = v.buildStructCache(typ, sName, v.rules[typ])
+	v.structCache.Set(typ, cs)
+	return cs
+}
+
+// buildStructCache builds the cStruct of the type using the map rules, which supersede the struct tags, see
+// RegisterStructValidationMapRules.
+func (v *Validate) buildStructCache(typ reflect.Type, sName string, rules map[string]string) *cStruct {
+
+	cs := &cStruct{name: sName, fields: make([]*cField, 0), fn: v.structLevelFuncs[typ]}
 
-	numFields := current.NumField()
-	rules := v.rules[typ]
+	numFields := typ.NumField()
+	fieldRules := splitFieldRules(rules)
 
-	var ctag *cTag
+	var ctag, modTags *cTag
+	var nested *cStruct
 	var fld reflect.StructField
-	var tag string
+	var tag, modTag string
 	var customName string
+	var skip bool
 
 	for i := 0; i < numFields; i++ {
 
@@ -130,16 +191,41 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 			continue
 		}
 
-		if rtag, ok := rules[fld.Name]; ok {
+		if rtag, ok := fieldRules[fld.Name][""]; ok {
 			tag = rtag
 		} else {
 			tag = fld.Tag.Get(v.tagName)
 		}
 
-		if tag == skipValidationTag {
+		nested = nil
+		if len(fieldRules[fld.Name]) > 0 {
+			tag, nested = v.resolveRulePaths(fld.Type, tag, fieldRules[fld.Name])
+		}
+
+		// modifiers within the validation tag, or the map rule, replace those of the 'mod' tag
+		if tag, modTag = v.splitModifiers(tag); len(modTag) == 0 {
+			modTag = fld.Tag.Get(modTagName)
+		}
+
+		// elements with rule paths are dived into to run the modifiers of their fields
+		if nested != nil && len(modTag) == 0 {
+			modTag = diveTags(tag)
+		}
+
+		modTags = v.parseModTags(fld, modTag)
+
+		// fields which aren't validated are only kept for their modifiers, or those of their nested structs, with
+		// fields skipped by encoding/json not being part of the data being validated
+		skip = tag == skipValidationTag || v.jsonFieldNames && isSkippedJSONField(fld)
+
+		if skip && (modTags == nil || !modTags.hasTag && !hasNestedStruct(fld.Type)) {
 			continue
 		}
 
+		if len(v.typeRules) > 0 && !skip && !v.hasMarker(tag, noTypeRulesTag) {
+			tag = v.applyTypeRules(fld.Type, tag)
+		}
+
 		customName = fld.Name
 
 		if v.hasTagNameFunc {
@@ -152,52 +238,461 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 		// NOTE: cannot use shared tag cache, because tags may be equal, but things like alias may be different
 		// and so only struct level caching can be used instead of combined with Field tag caching
 
-		if len(tag) > 0 {
-			ctag, _ = v.parseFieldTagsRecursive(tag, fld.Name, "", false)
-		} else {
-			// even if field doesn't have validations need cTag for traversing to potential inner/nested
-			// elements of the field.
-			ctag = new(cTag)
+		ctag = nil
+		if !skip {
+			if len(tag) > 0 {
+				ctag, _ = v.parseFieldTagsRecursive(tag, fld.Name, "", false)
+			}
+
+			if ctag == nil {
+				// even if field doesn't have validations need cTag for traversing to potential inner/nested
+				// elements of the field.
+				ctag = new(cTag)
+			}
+
+			v.checkValueTypes(fld.Type, ctag, fld.Name)
+		}
+
+		var altNames []string
+
+		if len(v.nameSchemes) > 0 {
+			altNames = make([]string, len(v.nameSchemes))
+
+			for j := 0; j < len(v.nameSchemes); j++ {
+				altNames[j] = v.nameSchemes[j].fn(fld)
+				if len(altNames[j]) == 0 {
+					altNames[j] = fld.Name
+				}
+			}
 		}
 
 		cs.fields = append(cs.fields, &cField{
 			idx:        i,
 			name:       fld.Name,
 			altName:    customName,
+			altNames:   altNames,
 			cTags:      ctag,
+			modTags:    modTags,
 			namesEqual: fld.Name == customName,
+			inline:     v.jsonFieldNames && isInlineJSONField(fld),
+			nested:     nested,
 		})
 	}
-	v.structCache.Set(typ, cs)
+
+	if v.jsonFieldNames {
+		if hidden := hiddenJSONFields(typ); len(hidden) > 0 {
+			cs = v.hideJSONFields(cs, typ, "", hidden)
+		}
+	}
+
 	return cs
 }
 
+// hideJSONFields returns a copy of the cStruct without the fields which encoding/json hides, the inlined embedded
+// structs containing them being given their own nested cStruct, see hiddenJSONFields.
+func (v *Validate) hideJSONFields(cs *cStruct, typ reflect.Type, prefix string, hidden map[string]struct{}) *cStruct {
+
+	hcs := &cStruct{name: cs.name, fields: make([]*cField, 0, len(cs.fields)), fn: cs.fn}
+
+	for _, f := range cs.fields {
+
+		path := prefix + strconv.Itoa(f.idx)
+
+		if _, ok := hidden[path]; ok {
+			continue
+		}
+
+		if f.inline && containsPathPrefix(hidden, path+".") {
+
+			ft := typ.Field(f.idx).Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			nested := f.nested
+			if nested == nil {
+				nested = v.buildStructCache(ft, ft.Name(), v.rules[ft])
+			}
+
+			hf := *f
+			hf.nested = v.hideJSONFields(nested, ft, path+".", hidden)
+			f = &hf
+		}
+
+		hcs.fields = append(hcs.fields, f)
+	}
+
+	return hcs
+}
+
+// containsPathPrefix returns whether any of the paths starts with the prefix.
+func containsPathPrefix(paths map[string]struct{}, prefix string) bool {
+
+	for path := range paths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitFieldRules splits map rules by the field they belong to, keyed by the remainder of their path eg. the rule
+// for "Items[*].SKU" is keyed by "[*].SKU" for the field Items and the rule for the field itself by "".
+func splitFieldRules(rules map[string]string) map[string]map[string]string {
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	fieldRules := make(map[string]map[string]string, len(rules))
+
+	for path, rule := range rules {
+
+		name, rest := path, ""
+		if idx := strings.IndexAny(path, ".["); idx != -1 {
+			name, rest = path[:idx], path[idx:]
+		}
+
+		if fieldRules[name] == nil {
+			fieldRules[name] = make(map[string]string)
+		}
+
+		fieldRules[name][rest] = rule
+	}
+
+	return fieldRules
+}
+
+// resolveRulePaths resolves the map rules for the paths within a field of the given type, returning the field's tag
+// and the cStruct to use for the field's struct value, or that of its elements, when there are rules for its fields.
+//
+// The rules for the elements of a slice, array or map eg. "Items[*]" are added after a dive, replacing those after the
+// dive of the field's own tag, if any.
+func (v *Validate) resolveRulePaths(typ reflect.Type, tag string, rules map[string]string) (string, *cStruct) {
+
+	var elemRules, structRules map[string]string
+
+	for path, rule := range rules {
+		switch {
+		case strings.HasPrefix(path, rulePathElem):
+			if elemRules == nil {
+				elemRules = make(map[string]string)
+			}
+			elemRules[path[len(rulePathElem):]] = rule
+
+		case strings.HasPrefix(path, namespaceSeparator):
+			if structRules == nil {
+				structRules = make(map[string]string)
+			}
+			structRules[path[len(namespaceSeparator):]] = rule
+		}
+	}
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	var nested *cStruct
+
+	if len(structRules) > 0 {
+
+		merged := make(map[string]string, len(v.rules[typ])+len(structRules))
+		for name, rule := range v.rules[typ] {
+			merged[name] = rule
+		}
+		for path, rule := range structRules {
+			merged[path] = rule
+		}
+
+		nested = v.buildStructCache(typ, typ.Name(), merged)
+	}
+
+	if len(elemRules) > 0 {
+
+		var elemTag string
+		elemTag, nested = v.resolveRulePaths(typ.Elem(), elemRules[""], elemRules)
+
+		tags := strings.Split(tag, tagSeparator)
+		for i := 0; i < len(tags); i++ {
+			if tags[i] == diveTag {
+				tags = tags[:i]
+				break
+			}
+		}
+
+		tags = append(tags, diveTag)
+		if len(elemTag) > 0 {
+			tags = append(tags, elemTag)
+		}
+
+		tag = strings.TrimPrefix(strings.Join(tags, tagSeparator), tagSeparator)
+	}
+
+	return tag, nested
+}
+
+// applyTypeRules adds the rules registered using RegisterTypeRules for the type to the tag, or for the type of its
+// elements after a dive, which is added when the tag doesn't have one.
+func (v *Validate) applyTypeRules(typ reflect.Type, tag string) string {
+
+	if typ == nil {
+		return tag
+	}
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if rules, ok := v.typeRules[typ]; ok {
+		return insertTypeRules(tag, rules)
+	}
+
+	if !v.elemHasTypeRules(typ) {
+		return tag
+	}
+
+	var tags []string
+	if len(tag) > 0 {
+		tags = strings.Split(tag, tagSeparator)
+	}
+
+	idx := -1
+	for i := 0; i < len(tags); i++ {
+		if tags[i] == diveTag {
+			idx = i
+			break
+		}
+	}
+
+	if idx == -1 {
+		return strings.Join(append(tags, diveTag, v.applyTypeRules(typ.Elem(), "")), tagSeparator)
+	}
+
+	// the rules for the values of a map are added after its keys
+	head, rest := tags[:idx+1], tags[idx+1:]
+
+	if typ.Kind() == reflect.Map && len(rest) > 0 && rest[0] == keysTag {
+		for i := 0; i < len(rest); i++ {
+			if rest[i] == endKeysTag {
+				head = tags[:idx+i+2]
+				rest = rest[i+1:]
+				break
+			}
+		}
+	}
+
+	elemTag := v.applyTypeRules(typ.Elem(), strings.Join(rest, tagSeparator))
+
+	return strings.Join(append(head[:len(head):len(head)], elemTag), tagSeparator)
+}
+
+// elemHasTypeRules returns if the elements of a slice, array or map type, or theirs, have rules registered using
+// RegisterTypeRules.
+func (v *Validate) elemHasTypeRules(typ reflect.Type) bool {
+
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return false
+	}
+
+	elem := typ.Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	if _, ok := v.typeRules[elem]; ok {
+		return true
+	}
+
+	return elem != typ && v.elemHasTypeRules(elem)
+}
+
+// insertTypeRules inserts the type rules before the tag's validations, after any leading omitempty, omitnil, omitzero
+// or required tags and markers so that they still apply to the type rules.
+func insertTypeRules(tag string, rules string) string {
+
+	if len(tag) == 0 {
+		return rules
+	}
+
+	tags := strings.Split(tag, tagSeparator)
+
+	i := 0
+	for ; i < len(tags); i++ {
+		switch tags[i] {
+		case omitempty, omitnil, omitzero, requiredTag, allErrorsTag, sensitiveTag, noTypeRulesTag:
+			continue
+		}
+		break
+	}
+
+	return strings.Join(append(append(tags[:i:i], rules), tags[i:]...), tagSeparator)
+}
+
+// setMarkers marks the tags, including the tags of their map keys, using the markers of the field they belong to.
+func setMarkers(ct *cTag, allErrors bool, sensitive bool) {
+
+	for ; ct != nil; ct = ct.next {
+		ct.allErrors = ct.allErrors || allErrors
+		ct.sensitive = ct.sensitive || sensitive
+		setMarkers(ct.keys, allErrors, sensitive)
+	}
+}
+
+// hasMarker returns whether the tag, or the tags of the aliases it uses, contains the marker eg. 'sensitive', markers
+// applying to all of the field's validations wherever they appear.
+func (v *Validate) hasMarker(tag string, marker string) bool {
+
+	for _, t := range strings.Split(tag, tagSeparator) {
+
+		t = strings.TrimPrefix(t, warnTagPrefix)
+		if t == marker {
+			return true
+		}
+
+		if tagsVal, ok := v.aliases[t]; ok && v.hasMarker(tagsVal, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkRulePath panics when the path of a map rule doesn't exist within the struct type, see
+// RegisterStructValidationMapRules.
+func checkRulePath(typ reflect.Type, path string) {
+
+	rest := path
+	current := typ
+
+	for {
+		for current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+
+		name := rest
+		if idx := strings.IndexAny(rest, ".["); idx != -1 {
+			name, rest = rest[:idx], rest[idx:]
+		} else {
+			rest = ""
+		}
+
+		if current.Kind() != reflect.Struct {
+			panic(fmt.Sprintf(invalidRulePath, path, typ, "'"+current.String()+"' is not a struct"))
+		}
+
+		fld, ok := current.FieldByName(name)
+		if !ok || len(fld.Index) != 1 {
+			panic(fmt.Sprintf(invalidRulePath, path, typ, "field '"+name+"' not found on '"+current.String()+"'"))
+		}
+
+		current = fld.Type
+
+		for strings.HasPrefix(rest, leftBracket) {
+
+			if !strings.HasPrefix(rest, rulePathElem) {
+				panic(fmt.Sprintf(invalidRulePath, path, typ, "only '"+rulePathElem+"' is supported for elements"))
+			}
+
+			for current.Kind() == reflect.Ptr {
+				current = current.Elem()
+			}
+
+			switch current.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				current = current.Elem()
+			default:
+				panic(fmt.Sprintf(invalidRulePath, path, typ, "'"+current.String()+"' is not a slice, array or map"))
+			}
+
+			rest = rest[len(rulePathElem):]
+		}
+
+		if len(rest) == 0 {
+			return
+		}
+
+		if !strings.HasPrefix(rest, namespaceSeparator) || len(rest) == 1 {
+			panic(fmt.Sprintf(invalidRulePath, path, typ, "invalid path"))
+		}
+
+		rest = rest[1:]
+	}
+}
+
 func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias string, hasAlias bool) (firstCtag *cTag, current *cTag) {
+	return v.parseTagsRecursive(tag, fieldName, alias, hasAlias, false)
+}
+
+// parseTagsRecursive parses the tags of a field into their cTags, which are the modifiers of a 'mod' tag when mod is
+// true, see Normalize, the modifiers only sharing the dive, keys and endkeys tags with the validations.
+func (v *Validate) parseTagsRecursive(tag string, fieldName string, alias string, hasAlias bool, mod bool) (firstCtag *cTag, current *cTag) {
 	var t string
 	noAlias := len(alias) == 0
 	tags := strings.Split(tag, tagSeparator)
+	allErrors, sensitive := v.hasMarker(tag, allErrorsTag), v.hasMarker(tag, sensitiveTag)
 
 	for i := 0; i < len(tags); i++ {
 		t = tags[i]
+
+		if mod && t != diveTag && t != keysTag && t != endKeysTag {
+			next := v.parseModifier(t, fieldName)
+			if current == nil {
+				firstCtag = next
+			} else {
+				current.next = next
+			}
+			current = next
+			continue
+		}
+
+		severity := SeverityError
+		if strings.HasPrefix(t, warnTagPrefix) {
+			t = t[len(warnTagPrefix):]
+			severity = SeverityWarning
+		}
+
+		// markers don't result in a cTag of their own but apply to all of the field's validations, see hasMarker
+		switch t {
+		case allErrorsTag, sensitiveTag:
+			continue
+
+		case noTypeRulesTag:
+			// only used when building the struct cache, see RegisterTypeRules
+			continue
+		}
+
 		if noAlias {
 			alias = t
 		}
 
 		// check map for alias and process new tags, otherwise process as usual
 		if tagsVal, found := v.aliases[t]; found {
-			if i == 0 {
+			var first *cTag
+			if current == nil {
 				firstCtag, current = v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
+				first = firstCtag
 			} else {
 				next, curr := v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
 				current.next, current = next, curr
+				first = next
+			}
 
+			if severity == SeverityWarning {
+				for ct := first; ct != nil; ct = ct.next {
+					ct.severity = severity
+				}
 			}
+
+			setMarkers(first, allErrors, sensitive)
 			continue
 		}
 
 		var prevTag tagType
 
-		if i == 0 {
+		if current == nil {
 			current = &cTag{aliasTag: alias, hasAlias: hasAlias, hasTag: true, typeof: typeDefault}
 			firstCtag = current
 		} else {
@@ -214,7 +709,7 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 		case keysTag:
 			current.typeof = typeKeys
 
-			if i == 0 || prevTag != typeDive {
+			if current == firstCtag || prevTag != typeDive {
 				panic(fmt.Sprintf("'%s' tag must be immediately preceded by the '%s' tag", keysTag, diveTag))
 			}
 
@@ -236,7 +731,8 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 				}
 			}
 
-			current.keys, _ = v.parseFieldTagsRecursive(string(b[:len(b)-1]), fieldName, "", false)
+			current.keys, _ = v.parseTagsRecursive(string(b[:len(b)-1]), fieldName, "", false, mod)
+			setMarkers(current.keys, allErrors, sensitive)
 			continue
 
 		case endKeysTag:
@@ -257,6 +753,10 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 			current.typeof = typeOmitNil
 			continue
 
+		case omitzero:
+			current.typeof = typeOmitZero
+			continue
+
 		case structOnlyTag:
 			current.typeof = typeStructOnly
 			continue
@@ -265,7 +765,29 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 			current.typeof = typeNoStructLevel
 			continue
 
+		case splitWSTag:
+			current.typeof = typeSplit
+			current.tag = splitWSTag
+			continue
+
 		default:
+			if vals := strings.SplitN(t, tagKeySeparator, 2); vals[0] == splitTag || vals[0] == splitTrimTag {
+
+				if len(vals) == 1 || len(vals[1]) == 0 {
+					panic(fmt.Sprintf(invalidSplitParam, vals[0], fieldName))
+				}
+
+				if noAlias {
+					current.aliasTag = vals[0]
+				}
+
+				current.typeof = typeSplit
+				current.tag = vals[0]
+				current.hasParam = true
+				current.param = strings.Replace(strings.Replace(vals[1], utf8HexComma, ",", -1), utf8Pipe, "|", -1)
+				continue
+			}
+
 			if t == isdefault {
 				current.typeof = typeIsDefault
 			}
@@ -286,6 +808,9 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 					current = current.next
 				}
 				current.hasParam = len(vals) > 1
+				current.severity = severity
+				current.allErrors = allErrors
+				current.sensitive = sensitive
 
 				current.tag = vals[0]
 				if len(current.tag) == 0 {
@@ -294,7 +819,11 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 
 				if wrapper, ok := v.validations[current.tag]; ok {
 					current.fn = wrapper.fn
+					current.async = v.asyncValidations[current.tag]
 					current.runValidationWhenNil = wrapper.runValidationOnNil
+					if _, ok = v.sensitiveTags[current.tag]; ok {
+						current.sensitive = true
+					}
 				} else {
 					panic(strings.TrimSpace(fmt.Sprintf(undefinedValidation, current.tag, fieldName)))
 				}
@@ -330,3 +859,56 @@ func (v *Validate) fetchCacheTag(tag string) *cTag {
 	}
 	return ctag
 }
+
+// checkValueTypes panics when a validation registered using RegisterValidationFor is used on a field, or the
+// elements of a field, of another type, or when a split tag is used on a value which isn't a string. Checking stops
+// at values whose type is only known at runtime.
+func (v *Validate) checkValueTypes(typ reflect.Type, ct *cTag, fieldName string) {
+
+	for ; ct != nil; ct = ct.next {
+
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if fn, _ := v.customTypeFunc(typ); fn != nil || typ.Kind() == reflect.Interface {
+			return
+		}
+
+		switch ct.typeof {
+		case typeSplit:
+			if typ.Kind() != reflect.String {
+				panic(fmt.Sprintf(invalidSplitType, ct.tag, fieldName, typ))
+			}
+
+			typ = stringSliceType
+
+		case typeDive:
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array:
+			case reflect.Map:
+				if ct.next != nil && ct.next.typeof == typeKeys {
+					v.checkValueTypes(typ.Key(), ct.next.keys, fieldName)
+					ct = ct.next
+				}
+			default:
+				return
+			}
+
+			typ = typ.Elem()
+
+		default:
+			want, ok := v.validationTypes[ct.tag]
+			if !ok {
+				continue
+			}
+
+			// the fields are dereferenced before being validated, so a pointer type is given a pointer to them
+			if want.Kind() == reflect.Interface && typ.Implements(want) || typ == want || want.Kind() == reflect.Ptr && want.Elem() == typ {
+				continue
+			}
+
+			panic(fmt.Sprintf(invalidValueType, ct.tag, fieldName, want, typ))
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+type rootNameCtxKey struct{}
+
+// ContextWithRootName returns a copy of ctx which overrides the name used as the
+// root of the namespaces of a validated struct, which is otherwise the struct's
+// Go type name, when passed to StructCtx and the other context aware methods.
+// An empty name omits the root from the namespaces altogether.
+//
+// eg. 'body.Name' instead of 'User.Name'
+//
+//	err := validate.StructCtx(validator.ContextWithRootName(ctx, "body"), user)
+//
+// NOTE: only the namespace is affected, the struct namespace always uses the
+// struct's Go type name.
+func ContextWithRootName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, rootNameCtxKey{}, name)
+}
+
+// Collector merges the ValidationErrors of multiple validations, such as the
+// separate validations of a request's headers, query parameters and body, into
+// a single ValidationErrors. The namespaces of the collected errors can be
+// prefixed to tell them apart and errors with the same namespace and tag are
+// only collected once.
+//
+// The zero value of a Collector is ready to use.
+//
+//	var c validator.Collector
+//
+//	if err := c.Add("query.page", validate.Var(page, "min=1")); err != nil {
+//		return err
+//	}
+//
+//	if err := c.Add("", validate.StructCtx(validator.ContextWithRootName(ctx, "body"), body)); err != nil {
+//		return err
+//	}
+//
+//	if err := c.Err(); err != nil {
+//		// err is ValidationErrors and can be translated as usual
+//	}
+//
+// NOTE: a Collector is not safe for concurrent use.
+type Collector struct {
+	errs ValidationErrors
+	seen map[collectorKey]struct{}
+}
+
+type collectorKey struct {
+	ns  string
+	tag string
+}
+
+// Add collects the FieldError's of err, which can be the error returned by
+// any of the Struct or Var methods, including when wrapped, or a single
+// FieldError. The prefix is prepended to the namespaces of the FieldError's,
+// separated by a '.', and for errors returned by the Var methods which have
+// no namespace of their own the prefix becomes the field's name.
+//
+// Add returns err when it isn't a validation error, such as an
+// InvalidValidationError, and nil otherwise.
+func (c *Collector) Add(prefix string, err error) error {
+
+	if err == nil {
+		return nil
+	}
+
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		for i := 0; i < len(errs); i++ {
+			c.add(prefix, errs[i])
+		}
+		return nil
+	}
+
+	var fe FieldError
+	if errors.As(err, &fe) {
+		c.add(prefix, fe)
+		return nil
+	}
+
+	return err
+}
+
+// AddMap collects the errors returned by ValidateMap, the keys of the map are
+// used as the namespaces of the errors and nested maps are collected under
+// their key. The prefix is prepended to all of the namespaces as with Add.
+//
+// AddMap returns the first error found in the map which isn't a validation
+// error, such as for a field which could not be dived into, and nil otherwise.
+func (c *Collector) AddMap(prefix string, errs map[string]interface{}) error {
+
+	keys := make([]string, 0, len(errs))
+	for k := range errs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var firstErr error
+
+	for _, k := range keys {
+
+		var err error
+
+		switch e := errs[k].(type) {
+		case map[string]interface{}:
+			err = c.AddMap(joinNamespace(prefix, k), e)
+		case error:
+			err = c.Add(joinNamespace(prefix, k), e)
+		}
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// Errors returns the collected ValidationErrors in the order they were added.
+func (c *Collector) Errors() ValidationErrors {
+	return c.errs
+}
+
+// Err returns the collected ValidationErrors as type error, nil when there
+// are none.
+func (c *Collector) Err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+func (c *Collector) add(prefix string, fe FieldError) {
+
+	if e, ok := fe.(*fieldError); ok && len(prefix) > 0 {
+
+		cp := *e
+		cp.ns = joinNamespace(prefix, e.ns)
+		cp.structNs = joinNamespace(prefix, e.structNs)
+
+		// the field is the whole namespace, as with errors from Var, so
+		// the field's name becomes the last segment of the new namespace
+		if int(e.fieldLen) == len(e.ns) {
+			cp.fieldLen = uint8(len(cp.ns) - strings.LastIndexByte(cp.ns, '.') - 1)
+		}
+
+		if int(e.structfieldLen) == len(e.structNs) {
+			cp.structfieldLen = uint8(len(cp.structNs) - strings.LastIndexByte(cp.structNs, '.') - 1)
+		}
+
+		fe = &cp
+	}
+
+	key := collectorKey{ns: fe.Namespace(), tag: fe.Tag()}
+
+	if _, ok := c.seen[key]; ok {
+		return
+	}
+
+	if c.seen == nil {
+		c.seen = make(map[collectorKey]struct{})
+	}
+
+	c.seen[key] = struct{}{}
+	c.errs = append(c.errs, fe)
+}
+
+// joinNamespace joins the prefix and namespace with a '.' unless the
+// namespace starts with an index, eg. '[0]' from diving using Var.
+func joinNamespace(prefix, ns string) string {
+
+	switch {
+	case len(prefix) == 0:
+		return ns
+	case len(ns) == 0:
+		return prefix
+	case ns[0] == '[':
+		return prefix + ns
+	default:
+		return prefix + "." + ns
+	}
+}
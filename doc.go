@@ -34,6 +34,32 @@ if the error returned is not nil, and if it's not check if error is
 InvalidValidationError ( if necessary, most of the time it isn't ) type cast
 it to type ValidationErrors like so err.(validator.ValidationErrors).
 
+ValidationErrors also work with errors.Is, errors.As and errors.Join, even when
+wrapped using fmt.Errorf("%w"). Each FieldError matches the TagError sentinel for
+the tag that failed and InvalidValidationError matches ErrInvalidValidation:
+
+	if errors.Is(err, validator.ErrRequired) {
+		// at least one field failed the 'required' tag
+	}
+
+	var fe validator.FieldError
+	if errors.As(err, &fe) {
+		// first FieldError
+	}
+
+# Error Ordering
+
+Struct fields are always validated, and their errors reported, in the order
+the fields are declared in, followed by the errors reported by struct level
+validations in the order they were reported, or preceded by them when the
+WithStructLevelBeforeFields option is used. Slice and array elements are
+validated in index order, however maps are validated in Go's randomized map
+iteration order unless the WithSortedMapKeys option is used. ValidationErrors
+can also be ordered by their namespace using Sort:
+
+	errs := err.(validator.ValidationErrors)
+	errs.Sort()
+
 # Custom Validation Functions
 
 Custom Validation functions can be added. Example:
@@ -52,6 +78,77 @@ Custom Validation functions can be added. Example:
 	// NOTES: using the same tag name as an existing function
 	//        will overwrite the existing one
 
+Custom Validation functions that need to report why they failed can be
+registered using RegisterValidationErr. The returned error is wrapped into
+the resulting FieldError, accessible via errors.As or errors.Unwrap, and any
+key/value details of a FuncError are available via the FieldErrorDetails
+interface for use in custom error messages and translations. Example:
+
+	func password(ctx context.Context, fl validator.FieldLevel) error {
+
+		if len(fl.Field().String()) < 8 {
+			return validator.NewFuncError("too short", "min", 8)
+		}
+
+		return nil
+	}
+
+	validate.RegisterValidationErr("password", password)
+
+Struct level validations run after the fields of the struct by default, and can
+check whether a field failed validation using the FieldFailed method of
+StructLevelFailures. Using the WithStructLevelSkipOnFieldErrors option they are
+skipped whenever a field of the struct, including those of nested structs,
+failed validation. Example:
+
+	func userStructLevel(sl validator.StructLevel) {
+
+		if sl.(validator.StructLevelFailures).FieldFailed("Password") {
+			return
+		}
+
+		// compare passwords ...
+	}
+
+# Asynchronous Validations
+
+Validations which perform I/O, such as checking that a username isn't already
+taken, can be registered using RegisterAsyncValidation. Instead of running when
+they're encountered they're run concurrently, once the synchronous validations
+have run, using up to 8 workers per call unless set using WithAsyncWorkers,
+each with a ctx derived from the call's which times out after the registered
+duration. Their errors are merged into the ValidationErrors in the order they
+would have been reported in had they run synchronously. Example:
+
+	validate.RegisterAsyncValidation("unique_username", func(ctx context.Context, fl validator.FieldLevel) error {
+		return users.CheckAvailable(ctx, fl.Field().String())
+	}, time.Second)
+
+	type User struct {
+		Username string `validate:"required,max=32,unique_username"`
+	}
+
+# Lookups
+
+The 'exists' and 'unique_in' tags validate that a value exists, or doesn't, within
+a source such as the column of a database table, using the LookupProvider set using
+WithLookupProvider. The lookups are asynchronous validations which are batched so
+that the values of a call with the same source are looked up at once, such as those
+of a dive over a slice of IDs. The ID of the record being updated can be excluded
+from the lookup by naming the field which holds it. Example:
+
+	type User struct {
+		ID      int
+		Email   string `validate:"required,email,unique_in=users.email ID"`
+		TeamIDs []int  `validate:"dive,exists=teams.id"`
+	}
+
+Errors returned by the LookupProvider, such as when the database is unavailable,
+are returned by the call instead of ValidationErrors, as they aren't failures of the
+values being validated.
+
+NewMemoryLookup returns an in-memory LookupProvider for use in tests.
+
 # Cross-Field Validation
 
 Cross-Field Validation can be done via the following tags:
@@ -124,6 +221,42 @@ Bad Validator definitions are not handled by the library. Example:
 
 	// this definition of min max will never succeed
 
+# Normalization
+
+Fields can be normalized before they are validated, such as trimming and
+lowercasing strings or filling in defaults, using the modifiers within their
+'mod' tag, which run in the order defined, and the value of their 'default'
+tag, which is set when the field is zero. The elements of slices, arrays and
+maps, and the keys of maps, are modified using the dive, keys and endkeys tags
+the same as when validating; Normalize returns a KeyCollisionError when two
+keys of a map are modified to the same key. Example:
+
+	type User struct {
+		Email string   `mod:"trim,lower" validate:"required,email"`
+		Tags  []string `mod:"dive,trim"`
+		Limit int      `default:"10" validate:"max=100"`
+	}
+
+	err := validate.NormalizeAndValidate(ctx, &user)
+
+Modifiers can also be given within the validation tag, or the map rules of
+RegisterStructValidationMapRules and Rules, in which case they replace those of
+the 'mod' tag, validations taking precedence over modifiers of the same name:
+
+	validate.RegisterStructValidationMapRules(map[string]string{
+		"Email":        "trim,lower,required,email",
+		"Items[*].SKU": "trim,upper,required",
+	}, Order{})
+
+The baked in modifiers are:
+  - trim, ltrim and rtrim remove white space, or the characters of the param eg. trim=/
+  - lower, upper and title change the case of letters
+  - collapse_ws replaces each run of white space with a single space
+  - nfc normalizes the string to Unicode Normalization Form C
+  - default=<value> sets zero values, allocating nil pointers, the same as the 'default' tag
+
+Custom modifiers can be added using RegisterModifier.
+
 # Using Validator Tags
 
 Baked In Cross-Field validation only compares fields on the same struct.
@@ -201,6 +334,75 @@ only for the nil-values).
 
 	Usage: omitnil
 
+# Omit Zero
+
+Allows to skip the validation if the value is the zero value of its type,
+like encoding/json's omitzero; unlike omitempty a pointer to a zero value is
+skipped as well. Types with an IsZero() bool method, such as time.Time, are
+zero when it returns true, which also applies to required, isdefault and the
+required_* and excluded_* validations.
+
+	Usage: omitzero
+
+# All Errors
+
+By default validation of a field stops at the first validation that fails.
+This tells the validator to continue with the remaining validations on the
+field so that every failing validation is reported; the remaining validations
+are still skipped when one of the required tags fails, and dive or nested
+struct validation only runs if all of the field's own validations pass. Like
+the other markers it applies to all of the field's validations wherever it
+appears within the field's tags, or an alias they use. This can be enabled for
+all fields using the WithAllFieldErrors option.
+
+	Usage: allerrors,min=8,containsany=0123456789,excludes=password
+
+# Sensitive
+
+This marks the field's values as sensitive so that they are never retained in
+the reported FieldError; Value() returns nil, or whatever is returned by the
+function registered using the WithValueRedactor option. The marker applies to
+all of the field's validations wherever it appears, including those of the
+elements and keys validated after a dive. Tags can be marked as sensitive for all
+fields using RegisterSensitiveTags, and the WithSensitiveTagRedaction option
+marks the baked in credit_card, luhn_checksum, jwt and ssn tags.
+
+	Usage: sensitive,min=12
+
+# No Type Rules
+
+This opts the field out of the rules registered for its type, or the type of
+its elements, using RegisterTypeRules. It may appear anywhere within the
+field's tags, or an alias they use.
+
+	Usage: notyperules
+
+# Warnings
+
+Prefixing a validation with 'warn:' gives it warning severity; when it fails a
+FieldError with SeverityWarning is reported but validation does not fail and
+the remaining validations on the field continue to run. Warnings are only
+returned when using StructResult, where they are kept separate from the errors;
+all of the other methods, including Struct, StructCtx, Var, ValidateMapErr and
+ValidateMapAs, drop them so that a value failing only warnings is valid. They
+can also be reported from struct level validations using the ReportWarning
+method of StructLevelWarnings. The severity of a FieldError is available using
+the FieldErrorSeverity interface.
+
+	type Test struct {
+		Field string `validate:"max=100,warn:max=50"`
+	}
+
+	res, err := validate.StructResult(t)
+	// res.Err() is nil when only warnings were reported
+	// res.Warnings contains the warnings
+
+The Result can be marshaled as JSON, each of the errors and warnings being an
+object with its namespace, field, tag, param, value and severity. The JSON of
+ValidationErrors themselves is unaffected.
+
+	Usage: warn:max=50
+
 # Dive
 
 This tells the validator to dive into a slice, array or map and validate that
@@ -249,6 +451,29 @@ Example #2
 	// eq=1|eq=2 will be applied to each array element in the map keys
 	// required will be applied to map values
 
+# Split
+
+This splits a string field by the separator given in the param, the tags that
+follow being applied to the resulting []string so that, like a slice, its
+elements can be validated using dive, with namespaces such as 'Recipients[2]'.
+splittrim also trims the whitespace around each of the elements and splitws
+splits the string on whitespace. An empty string has no elements. Commas and
+pipes must be given using their UTF-8 hex representation, 0x2C and 0x7C.
+
+	Usage: split=0x2C
+	Usage: splittrim=;
+	Usage: splitws
+
+Example #1
+
+	Recipients string `validate:"split=0x2C,min=1,dive,email"`
+	// min=1 will be applied to the elements as a whole
+	// email will be applied to each of the elements
+
+Example #2
+
+	Roles string `validate:"omitempty,splitws,dive,oneof=admin user"`
+
 # Required
 
 This validates that the value is not the data types default zero value.
@@ -395,6 +620,23 @@ opposite of required.
 
 	Usage: isdefault
 
+# Custom Lengths and Ordering
+
+Types which aren't slices, arrays, maps, strings or numbers can be used with the
+length and comparison validations below, such as len, min, max, gt, eq and their
+field counterparts like gtfield, by implementing Len() int, which is used as the
+value's length, or a Compare or Cmp method taking a value of the type, or a
+pointer to one, and returning -1, 0 or +1, like big.Int. Params are parsed into
+the type using its ParseParam(string) method, which returns the value and
+optionally an error, or its UnmarshalText method.
+
+	type Decimal struct { ... }
+
+	func (d Decimal) Cmp(other Decimal) int { ... }
+	func (Decimal) ParseParam(s string) (Decimal, error) { ... }
+
+	Usage: gt=0.5,ltfield=Max
+
 # Length
 
 For numbers, length will ensure that the value is
@@ -515,7 +757,8 @@ Example #1
 
 Example #2 (time.Time)
 
-For time.Time ensures the time value is greater than time.Now.UTC().
+For time.Time ensures the time value is greater than time.Now.UTC(), or the
+current time of the clock set using WithClock or ContextWithClock.
 
 	Usage: gt
 
@@ -536,7 +779,8 @@ Example #1
 
 Example #2 (time.Time)
 
-For time.Time ensures the time value is greater than or equal to time.Now.UTC().
+For time.Time ensures the time value is greater than or equal to time.Now.UTC(), or the
+current time of the clock set using WithClock or ContextWithClock.
 
 	Usage: gte
 
@@ -559,7 +803,8 @@ Example #1
 
 Example #2 (time.Time)
 
-For time.Time ensures the time value is less than time.Now.UTC().
+For time.Time ensures the time value is less than time.Now.UTC(), or the
+current time of the clock set using WithClock or ContextWithClock.
 
 	Usage: lt
 
@@ -580,7 +825,8 @@ Example #1
 
 Example #2 (time.Time)
 
-For time.Time ensures the time value is less than or equal to time.Now.UTC().
+For time.Time ensures the time value is less than or equal to time.Now.UTC(), or the
+current time of the clock set using WithClock or ContextWithClock.
 
 	Usage: lte
 
@@ -591,6 +837,51 @@ duration given in the parameter.
 
 	Usage: lte=1h30m
 
+# Before
+
+For time.Time, and strings containing a RFC 3339 date-time or date, ensures the
+time is before the parameter, which is either now, relative to now, or a RFC 3339
+date-time or date. Durations are those accepted by time.ParseDuration or a whole
+number of days or weeks eg. 30d or 2w. Strings which can't be parsed fail.
+
+The parameter can be followed by a space and the layout, as accepted by
+time.Parse, of string fields, in which case an absolute time is also parsed
+using the layout first. The time part of the parameter can't contain spaces.
+
+	Usage: before=now+1h
+	Usage: before=2030-01-01
+	Usage: before=now 2006-01-02 15:04:05
+
+# After
+
+Same as 'before' except that it ensures the time is after the parameter.
+
+	Usage: after=now
+	Usage: after=2020-01-01T00:00:00Z
+	Usage: after=01/01/2020 02/01/2006
+
+# Within
+
+Ensures the time is within the duration of now, either before or after it.
+
+	Usage: within=24h
+	Usage: within=24h 02/01/2006
+
+# Not Older Than
+
+Ensures the time is no more than the duration before now, times in the future
+being valid.
+
+	Usage: not_older_than=30d
+	Usage: not_older_than=30d 2006-01-02 15:04
+
+The current time used by the time validations is time.Now, unless a clock is
+set using WithClock or for a single call using ContextWithClock:
+
+	validate := validator.New(validator.WithClock(func() time.Time {
+		return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	}))
+
 # Field Equals Another Field
 
 This will validate the field value against another fields value either within
@@ -1176,6 +1467,52 @@ This validates that a string value contains a valid v6 CIDR Address.
 
 	Usage: cidrv6
 
+# Network Types
+
+The IP, CIDR, TCP, UDP, hostname_port, URL and URI validations accept the
+net/netip Addr, Prefix and AddrPort types, net.IP and url.URL as well as
+strings, validating their string representation. Invalid netip values and
+empty net.IP's fail the validations. Like time.Time, the netip and url.URL
+structs are validated as values rather than as nested structs.
+
+# IP Address Within
+
+This validates that the value is an IP address within one of the space
+separated prefixes, or equal to one of the addresses, of the param. It
+accepts strings, netip.Addr, netip.AddrPort and net.IP, with IPv4-mapped
+IPv6 addresses being treated as IPv4.
+
+	Usage: ip_in=10.0.0.0/8 192.168.0.0/16 ::1
+
+# Public IP Address
+
+This validates that the value is a global unicast IP address which isn't
+private, thus excluding loopback, link-local, multicast and unspecified
+addresses. It accepts the same types as ip_in.
+
+	Usage: public_ip
+
+# Private IP Address
+
+This validates that the value is a private IP address as per RFC 1918 for
+IPv4 and RFC 4193 for IPv6. It accepts the same types as ip_in.
+
+	Usage: private_ip
+
+# Loopback IP Address
+
+This validates that the value is a loopback IP address. It accepts the same
+types as ip_in.
+
+	Usage: loopback_ip
+
+# Multicast IP Address
+
+This validates that the value is a multicast IP address. It accepts the same
+types as ip_in.
+
+	Usage: multicast_ip
+
 # Transmission Control Protocol Address TCP
 
 This validates that a string value contains a valid resolvable TCP Address.
@@ -2,20 +2,105 @@ package validator
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	ut "github.com/go-playground/universal-translator"
 )
 
 const (
-	fieldErrMsg = "Key: '%s' Error:Field validation for '%s' failed on the '%s' tag"
+	fieldErrMsg       = "Key: '%s' Error:Field validation for '%s' failed on the '%s' tag"
+	fieldErrReasonMsg = fieldErrMsg + ": %s"
 )
 
 // ValidationErrorsTranslations is the translation return type
 type ValidationErrorsTranslations map[string]string
 
+// ResultTranslations is the translation return type of a Result
+type ResultTranslations struct {
+	Errors   ValidationErrorsTranslations `json:"errors,omitempty"`
+	Warnings ValidationErrorsTranslations `json:"warnings,omitempty"`
+}
+
+// Severity is the severity of a FieldError
+type Severity uint8
+
+// Severity values
+const (
+	// SeverityError fails validation, it is the default for all validations.
+	SeverityError Severity = iota
+
+	// SeverityWarning is reported without failing validation, validations are
+	// given warning severity by prefixing their tag with 'warn:' eg. warn:max=50
+	SeverityWarning
+)
+
+// String returns the Severity's name
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText returns the Severity's name for use in encodings such as JSON
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Result contains the outcome of a validation where the warnings are kept
+// separate from the errors, see StructResult.
+type Result struct {
+	Errors   ValidationErrors `json:"errors,omitempty"`
+	Warnings ValidationErrors `json:"warnings,omitempty"`
+}
+
+// MarshalJSON returns the JSON representation of the Result, in which each of
+// the errors and warnings is an object with its namespace, field, tag, param,
+// value, severity and the reason returned by the validation, if any.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Errors   []fieldErrorJSON `json:"errors,omitempty"`
+		Warnings []fieldErrorJSON `json:"warnings,omitempty"`
+	}{
+		Errors:   newFieldErrorsJSON(r.Errors),
+		Warnings: newFieldErrorsJSON(r.Warnings),
+	})
+}
+
+// Err returns the Result's errors as type error, nil when there are none.
+// Warnings never cause an error to be returned.
+func (r *Result) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	return r.Errors
+}
+
+// Translate translates all of the Result's errors and warnings
+func (r *Result) Translate(ut ut.Translator) ResultTranslations {
+
+	var trans ResultTranslations
+
+	if len(r.Errors) > 0 {
+		trans.Errors = r.Errors.Translate(ut)
+	}
+
+	if len(r.Warnings) > 0 {
+		trans.Warnings = r.Warnings.Translate(ut)
+	}
+
+	return trans
+}
+
 // InvalidValidationError describes an invalid argument passed to
 // `Struct`, `StructExcept`, StructPartial` or `Field`
 type InvalidValidationError struct {
@@ -32,6 +117,161 @@ func (e *InvalidValidationError) Error() string {
 	return "validator: (nil " + e.Type.String() + ")"
 }
 
+// Is allows InvalidValidationError to be compared using errors.Is, it matches any
+// InvalidValidationError with the same Type or, when the target has no Type such as
+// ErrInvalidValidation, any InvalidValidationError at all.
+func (e *InvalidValidationError) Is(target error) bool {
+	t, ok := target.(*InvalidValidationError)
+	return ok && (t.Type == nil || t.Type == e.Type)
+}
+
+// ErrInvalidValidation matches any InvalidValidationError when used with errors.Is
+var ErrInvalidValidation error = &InvalidValidationError{}
+
+// KeyCollisionError is returned by Normalize when modifying the keys of a map,
+// using the keys tag, results in two of its keys being the same.
+type KeyCollisionError struct {
+	Namespace string        // the struct namespace of the map eg. 'User.Labels'
+	Keys      []interface{} // the two keys, ordered by their representation
+	Key       interface{}   // the key they're both modified to
+}
+
+func newKeyCollisionError(ns string, a, b, key interface{}) *KeyCollisionError {
+
+	if fmt.Sprint(a) > fmt.Sprint(b) {
+		a, b = b, a
+	}
+
+	return &KeyCollisionError{Namespace: ns, Keys: []interface{}{a, b}, Key: key}
+}
+
+// Error returns KeyCollisionError message
+func (e *KeyCollisionError) Error() string {
+	return fmt.Sprintf("validator: the keys '%v' and '%v' of '%s' are both modified to '%v'", e.Keys[0], e.Keys[1], e.Namespace, e.Key)
+}
+
+// TagError is a sentinel error which matches any FieldError that failed on the
+// tag it names when used with errors.Is, either directly or via wrapped
+// ValidationErrors eg. errors.Is(err, validator.ErrRequired).
+//
+// Sentinels are provided for the most common baked in tags, for any other tag
+// including your own custom ones TagError can be used directly eg.
+// errors.Is(err, validator.TagError("mytag"))
+type TagError string
+
+// Error returns the TagError message
+func (e TagError) Error() string {
+	return "validator: failed on the '" + string(e) + "' tag"
+}
+
+// Sentinel errors for baked in tags, see TagError
+const (
+	ErrRequired           TagError = requiredTag
+	ErrRequiredIf         TagError = requiredIfTag
+	ErrRequiredUnless     TagError = requiredUnlessTag
+	ErrRequiredWith       TagError = requiredWithTag
+	ErrRequiredWithAll    TagError = requiredWithAllTag
+	ErrRequiredWithout    TagError = requiredWithoutTag
+	ErrRequiredWithoutAll TagError = requiredWithoutAllTag
+	ErrExcludedIf         TagError = excludedIfTag
+	ErrExcludedUnless     TagError = excludedUnlessTag
+	ErrExcludedWith       TagError = excludedWithTag
+	ErrExcludedWithAll    TagError = excludedWithAllTag
+	ErrExcludedWithout    TagError = excludedWithoutTag
+	ErrExcludedWithoutAll TagError = excludedWithoutAllTag
+	ErrIsDefault          TagError = isdefault
+	ErrLen                TagError = "len"
+	ErrMin                TagError = "min"
+	ErrMax                TagError = "max"
+	ErrEq                 TagError = "eq"
+	ErrNe                 TagError = "ne"
+	ErrLt                 TagError = "lt"
+	ErrLte                TagError = "lte"
+	ErrGt                 TagError = "gt"
+	ErrGte                TagError = "gte"
+	ErrEqField            TagError = "eqfield"
+	ErrNeField            TagError = "nefield"
+	ErrGtField            TagError = "gtfield"
+	ErrGteField           TagError = "gtefield"
+	ErrLtField            TagError = "ltfield"
+	ErrLteField           TagError = "ltefield"
+	ErrOneOf              TagError = "oneof"
+	ErrUnique             TagError = "unique"
+	ErrAlpha              TagError = "alpha"
+	ErrAlphanum           TagError = "alphanum"
+	ErrNumeric            TagError = "numeric"
+	ErrNumber             TagError = "number"
+	ErrBoolean            TagError = "boolean"
+	ErrContains           TagError = "contains"
+	ErrExcludes           TagError = "excludes"
+	ErrStartsWith         TagError = "startswith"
+	ErrEndsWith           TagError = "endswith"
+	ErrLowercase          TagError = "lowercase"
+	ErrUppercase          TagError = "uppercase"
+	ErrEmail              TagError = "email"
+	ErrURL                TagError = "url"
+	ErrURI                TagError = "uri"
+	ErrUUID               TagError = "uuid"
+	ErrIP                 TagError = "ip"
+	ErrIPv4               TagError = "ipv4"
+	ErrIPv6               TagError = "ipv6"
+	ErrCIDR               TagError = "cidr"
+	ErrHostname           TagError = "hostname"
+	ErrFQDN               TagError = "fqdn"
+	ErrE164               TagError = "e164"
+	ErrJSON               TagError = "json"
+	ErrJWT                TagError = "jwt"
+	ErrDatetime           TagError = "datetime"
+	ErrTimezone           TagError = "timezone"
+	ErrCreditCard         TagError = "credit_card"
+
+	// ErrUnknownKey and ErrObject are reported by ValidateMapErr, for keys
+	// without rules when using WithStrictMaps and for values which should
+	// be objects according to the rules but aren't.
+	ErrUnknownKey TagError = unknownKeyTag
+	ErrObject     TagError = objectTag
+
+	// ErrType is reported by ValidateMapAs for values which can't be
+	// assigned to their field.
+	ErrType TagError = typeTag
+)
+
+// FuncError can be returned from a FuncErr validation to describe the reason the
+// validation failed along with key/value details, which are made available via
+// FieldErrorDetails for use in custom error messages and translations.
+type FuncError struct {
+	Reason  string
+	Details map[string]interface{}
+}
+
+// NewFuncError returns a new FuncError with the provided reason and details
+// passed as alternating key/value pairs.
+//
+// eg. validator.NewFuncError("missing digit", "min_digits", 1)
+func NewFuncError(reason string, keyvals ...interface{}) *FuncError {
+
+	if len(keyvals)%2 != 0 {
+		panic("NewFuncError: odd number of key/value arguments")
+	}
+
+	fe := &FuncError{Reason: reason}
+
+	if len(keyvals) > 0 {
+		fe.Details = make(map[string]interface{}, len(keyvals)/2)
+
+		for i := 0; i < len(keyvals); i += 2 {
+			fe.Details[fmt.Sprint(keyvals[i])] = keyvals[i+1]
+		}
+	}
+
+	return fe
+}
+
+// Error returns the FuncError's reason
+func (e *FuncError) Error() string {
+	return e.Reason
+}
+
 // ValidationErrors is an array of FieldError's
 // for use in custom error messages post validation.
 type ValidationErrors []FieldError
@@ -53,6 +293,42 @@ func (ve ValidationErrors) Error() string {
 	return strings.TrimSpace(buff.String())
 }
 
+// Unwrap returns the FieldError's as a slice of errors allowing errors.Is and
+// errors.As to inspect the individual FieldError's.
+func (ve ValidationErrors) Unwrap() []error {
+
+	errs := make([]error, len(ve))
+
+	for i := 0; i < len(ve); i++ {
+		errs[i] = ve[i]
+	}
+
+	return errs
+}
+
+// Is reports whether any of the FieldError's match the target, allowing
+// errors.Is to match sentinel errors such as ErrRequired.
+func (ve ValidationErrors) Is(target error) bool {
+
+	for i := 0; i < len(ve); i++ {
+		if errors.Is(ve[i], target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sort sorts the ValidationErrors by their Namespace, comparing numbers within
+// the namespace such as slice indexes by their value so that 'Items[2]' is
+// ordered before 'Items[10]'. The order of errors with equal namespaces, such
+// as multiple failing validations on the same field, is preserved.
+func (ve ValidationErrors) Sort() {
+	sort.SliceStable(ve, func(i, j int) bool {
+		return comparePath(ve[i].Namespace(), ve[j].Namespace()) < 0
+	})
+}
+
 // Translate translates all of the ValidationErrors
 func (ve ValidationErrors) Translate(ut ut.Translator) ValidationErrorsTranslations {
 
@@ -157,8 +433,58 @@ type FieldError interface {
 	Error() string
 }
 
+// FieldErrorDetails is implemented by the FieldErrors reported by the validator,
+// it's kept apart from FieldError so that adding it doesn't break the existing
+// implementations of FieldError eg.
+//
+//	if d, ok := fe.(validator.FieldErrorDetails); ok {
+//	    min, _ := d.Details()["min"]
+//	}
+type FieldErrorDetails interface {
+
+	// Unwrap returns the error returned by a validation registered using
+	// RegisterValidationErr, or nil for all other validations.
+	Unwrap() error
+
+	// Details returns the key/value details of a FuncError returned by a
+	// validation registered using RegisterValidationErr, if any.
+	Details() map[string]interface{}
+}
+
+// FieldErrorSeverity is implemented by the FieldErrors reported by the validator,
+// kept apart from FieldError the same as FieldErrorDetails.
+type FieldErrorSeverity interface {
+
+	// Severity returns the FieldError's severity, SeverityWarning when the
+	// validation was prefixed with 'warn:' or reported using ReportWarning.
+	Severity() Severity
+}
+
+// FieldErrorNames is implemented by the FieldErrors reported by the validator,
+// kept apart from FieldError the same as FieldErrorDetails.
+type FieldErrorNames interface {
+
+	// FieldAs returns the field's name under the naming scheme registered
+	// using RegisterTagNameFuncFor, or the same as Field when the scheme
+	// isn't registered or the name can't be determined.
+	//
+	// eq. form name "first_name"
+	FieldAs(scheme string) string
+
+	// NamespaceAs returns the namespace for the field error using the names
+	// of the naming scheme registered using RegisterTagNameFuncFor, or the
+	// same as Namespace when the scheme isn't registered or the names can't
+	// be determined.
+	//
+	// eq. form names "User.first_name"
+	NamespaceAs(scheme string) string
+}
+
 // compile time interface checks
 var _ FieldError = new(fieldError)
+var _ FieldErrorDetails = new(fieldError)
+var _ FieldErrorSeverity = new(fieldError)
+var _ FieldErrorNames = new(fieldError)
 var _ error = new(fieldError)
 
 // fieldError contains a single field's validation error along
@@ -176,6 +502,64 @@ type fieldError struct {
 	param          string
 	kind           reflect.Kind
 	typ            reflect.Type
+	err            error
+	severity       Severity
+	path           []pathField // fields of the namespace, only when naming schemes are registered
+	nsScheme       string      // naming scheme used for ns, empty for the default names
+}
+
+// pathField is the names of a field within a fieldError's namespace
+type pathField struct {
+	altName  string
+	altNames []string
+}
+
+// fieldErrorJSON is the JSON representation of a FieldError within a Result
+type fieldErrorJSON struct {
+	Namespace string          `json:"namespace"`
+	Field     string          `json:"field"`
+	Tag       string          `json:"tag"`
+	Param     string          `json:"param,omitempty"`
+	Value     json.RawMessage `json:"value,omitempty"`
+	Severity  Severity        `json:"severity"`
+	Reason    string          `json:"reason,omitempty"`
+}
+
+// newFieldErrorsJSON returns the JSON representations of the errors, the
+// values being omitted when they cannot themselves be represented as JSON.
+func newFieldErrorsJSON(errs ValidationErrors) []fieldErrorJSON {
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	fejs := make([]fieldErrorJSON, len(errs))
+
+	for i, fe := range errs {
+
+		fejs[i] = fieldErrorJSON{
+			Namespace: fe.Namespace(),
+			Field:     fe.Field(),
+			Tag:       fe.Tag(),
+			Param:     fe.Param(),
+		}
+
+		if fes, ok := fe.(FieldErrorSeverity); ok {
+			fejs[i].Severity = fes.Severity()
+		}
+
+		if fe.Value() != nil {
+			if b, err := json.Marshal(fe.Value()); err == nil {
+				fejs[i].Value = b
+			}
+		}
+
+		if fed, ok := fe.(FieldErrorDetails); ok && fed.Unwrap() != nil {
+			fejs[i].Reason = fed.Unwrap().Error()
+		}
+	}
+
+	return fejs
 }
 
 // Tag returns the validation tag that failed.
@@ -224,6 +608,62 @@ func (fe *fieldError) StructField() string {
 	return fe.structNs[len(fe.structNs)-int(fe.structfieldLen):]
 }
 
+// FieldAs returns the field's name under the naming scheme registered using
+// RegisterTagNameFuncFor.
+func (fe *fieldError) FieldAs(scheme string) string {
+
+	idx := fe.v.nameSchemeIndex(scheme)
+	if idx < 0 || len(fe.path) == 0 {
+		return fe.Field()
+	}
+
+	return fe.path[len(fe.path)-1].name(idx)
+}
+
+// NamespaceAs returns the namespace for the field error using the names of the
+// naming scheme registered using RegisterTagNameFuncFor.
+func (fe *fieldError) NamespaceAs(scheme string) string {
+
+	idx := fe.v.nameSchemeIndex(scheme)
+	if idx < 0 || len(fe.path) == 0 {
+		return fe.ns
+	}
+
+	// only the part of the namespace built from the field names is replaced,
+	// keeping the root and any prefixes such as those added by a Collector
+	suffix := fe.pathNamespace(fe.v.nameSchemeIndex(fe.nsScheme))
+	if !strings.HasSuffix(fe.ns, suffix) {
+		return fe.ns
+	}
+
+	return fe.ns[:len(fe.ns)-len(suffix)] + fe.pathNamespace(idx)
+}
+
+// pathNamespace joins the names of the fields under the naming scheme at idx,
+// or the default names when idx is negative.
+func (fe *fieldError) pathNamespace(idx int) string {
+
+	var b strings.Builder
+
+	for i := 0; i < len(fe.path); i++ {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(fe.path[i].name(idx))
+	}
+
+	return b.String()
+}
+
+// name returns the field's name under the naming scheme at idx, or the
+// default name when idx is negative.
+func (pf pathField) name(idx int) string {
+	if idx < 0 || idx >= len(pf.altNames) {
+		return pf.altName
+	}
+	return pf.altNames[idx]
+}
+
 // Value returns the actual field's value in case needed for creating the error
 // message
 func (fe *fieldError) Value() interface{} {
@@ -246,8 +686,37 @@ func (fe *fieldError) Type() reflect.Type {
 	return fe.typ
 }
 
+// Is reports whether the target is a TagError naming the tag that failed
+func (fe *fieldError) Is(target error) bool {
+	t, ok := target.(TagError)
+	return ok && (string(t) == fe.tag || string(t) == fe.actualTag)
+}
+
+// Unwrap returns the error returned by the validation function, if any
+func (fe *fieldError) Unwrap() error {
+	return fe.err
+}
+
+// Details returns the key/value details of a FuncError returned by the
+// validation function, if any.
+func (fe *fieldError) Details() map[string]interface{} {
+	var fnErr *FuncError
+	if errors.As(fe.err, &fnErr) {
+		return fnErr.Details
+	}
+	return nil
+}
+
+// Severity returns the fieldError's severity
+func (fe *fieldError) Severity() Severity {
+	return fe.severity
+}
+
 // Error returns the fieldError's error message
 func (fe *fieldError) Error() string {
+	if fe.err != nil {
+		return fmt.Sprintf(fieldErrReasonMsg, fe.ns, fe.Field(), fe.tag, fe.err.Error())
+	}
 	return fmt.Sprintf(fieldErrMsg, fe.ns, fe.Field(), fe.tag)
 }
 
@@ -0,0 +1,65 @@
+//go:build go1.20
+// +build go1.20
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/go-playground/assert/v2"
+)
+
+func TestValidationErrorsJoin(t *testing.T) {
+	type Query struct {
+		Page int `validate:"gte=1"`
+	}
+
+	type Body struct {
+		Name string `validate:"required"`
+	}
+
+	validate := New()
+
+	err := errors.Join(
+		validate.Struct(Query{}),
+		validate.Struct(Body{}),
+		validate.Var("", "email"),
+	)
+	NotEqual(t, err, nil)
+	Equal(t, errors.Is(err, ErrGte), true)
+	Equal(t, errors.Is(err, ErrRequired), true)
+	Equal(t, errors.Is(err, ErrEmail), true)
+	Equal(t, errors.Is(err, ErrMax), false)
+
+	var fe FieldError
+	Equal(t, errors.As(fmt.Errorf("request: %w", err), &fe), true)
+	Equal(t, fe.Namespace(), "Query.Page")
+
+	var ve ValidationErrors
+	Equal(t, errors.As(err, &ve), true)
+	Equal(t, len(ve), 1)
+	Equal(t, ve[0].Tag(), "gte")
+
+	// FuncErr errors remain reachable through the whole chain
+	sentinel := errors.New("reserved")
+
+	err = validate.RegisterValidationErr("username", func(ctx context.Context, fl FieldLevel) error {
+		if fl.Field().String() == "admin" {
+			return fmt.Errorf("username %q: %w", fl.Field().String(), sentinel)
+		}
+		return nil
+	})
+	Equal(t, err, nil)
+
+	err = errors.Join(validate.Var("admin", "username"), validate.Struct(Body{}))
+	Equal(t, errors.Is(err, sentinel), true)
+	Equal(t, errors.Is(err, TagError("username")), true)
+
+	var ive *InvalidValidationError
+	err = errors.Join(validate.Struct(Body{Name: "name"}), validate.Struct(nil))
+	Equal(t, errors.As(err, &ive), true)
+	Equal(t, errors.Is(err, ErrInvalidValidation), true)
+}
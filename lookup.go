@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	existsTag   = "exists"
+	uniqueInTag = "unique_in"
+)
+
+// LookupRequest is a batch of values to look up within a source, see LookupProvider.
+type LookupRequest struct {
+	// Source is the source of the values given by the tag's param eg. 'users.email' for 'unique_in=users.email'
+	Source string
+
+	// Values are the values of the fields to look up
+	Values []interface{}
+
+	// Exclude is the ID of the record to exclude from the lookup eg. the record being updated, which is the value of
+	// the field named by the tag's param eg. 'unique_in=users.email ID', or nil when it's zero or not given.
+	Exclude interface{}
+}
+
+// LookupProvider looks up whether values exist within a source, such as the column of a database table, for the
+// 'exists' and 'unique_in' tags, see WithLookupProvider.
+type LookupProvider interface {
+
+	// Lookup returns whether each of the request's values exists within its source, indexed the same as the values.
+	// It's called once for all of the values of a call with the same source and excluded record, such as those of a
+	// dive over a slice of IDs. A returned error, such as when the datastore is unavailable, is returned by the call
+	// eg. by StructCtx instead of its ValidationErrors, rather than failing the validations.
+	Lookup(ctx context.Context, req LookupRequest) ([]bool, error)
+}
+
+// WithLookupProvider registers the 'exists' and 'unique_in' tags using the LookupProvider, which validate that the
+// value exists within the source given by the tag's param, or doesn't for 'unique_in', eg. `validate:"exists=users.id"`.
+//
+// The lookups are asynchronous validations, see RegisterAsyncValidation, which are batched so that the values of all
+// the fields of a call with the same source and excluded record are looked up at once. The ID of a record to exclude,
+// such as the one being updated, can be given by naming the field which holds it eg. `validate:"unique_in=users.email ID"`.
+//
+// See NewMemoryLookup for a LookupProvider for use in tests.
+func WithLookupProvider(provider LookupProvider) Option {
+	return func(v *Validate) {
+		_ = v.registerAsyncValidation(existsTag, &asyncValidation{batch: lookupBatch(provider, false)})
+		_ = v.registerAsyncValidation(uniqueInTag, &asyncValidation{batch: lookupBatch(provider, true)})
+	}
+}
+
+// lookupBatch returns the batched validation for the 'exists' tag, or the 'unique_in' tag when unique, which looks up
+// the values of the fields grouped by their source and excluded record.
+func lookupBatch(provider LookupProvider, unique bool) func(ctx context.Context, fls []FieldLevel) ([]error, error) {
+
+	return func(ctx context.Context, fls []FieldLevel) ([]error, error) {
+
+		type group struct {
+			req LookupRequest
+			idx []int
+		}
+
+		// the type is part of the key so that eg. the excluded records 1 and "1" aren't grouped together
+		type groupKey struct {
+			source  string
+			typ     reflect.Type
+			exclude string
+		}
+
+		var groups []*group
+		keys := make(map[groupKey]*group)
+
+		for i, fl := range fls {
+
+			params := strings.Fields(fl.Param())
+			if len(params) == 0 || len(params) > 2 {
+				panic(fmt.Sprintf("Bad param '%s' for '%s', must be a source optionally followed by a field eg. users.email ID", fl.Param(), fl.GetTag()))
+			}
+
+			var exclude interface{}
+
+			if len(params) == 2 {
+				field, _, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), params[1])
+				if !found {
+					panic(fmt.Sprintf("Field '%s' not found for '%s'", params[1], fl.GetTag()))
+				}
+
+				if field.IsValid() && !isZero(field) {
+					exclude = getValue(field)
+				}
+			}
+
+			key := groupKey{source: params[0], typ: reflect.TypeOf(exclude), exclude: fmt.Sprint(exclude)}
+
+			g, ok := keys[key]
+			if !ok {
+				g = &group{req: LookupRequest{Source: params[0], Exclude: exclude}}
+				keys[key] = g
+				groups = append(groups, g)
+			}
+
+			g.req.Values = append(g.req.Values, getValue(fl.Field()))
+			g.idx = append(g.idx, i)
+		}
+
+		errs := make([]error, len(fls))
+
+		for _, g := range groups {
+
+			found, err := provider.Lookup(ctx, g.req)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(found) != len(g.req.Values) {
+				return nil, fmt.Errorf("validator: LookupProvider returned %d results for %d values", len(found), len(g.req.Values))
+			}
+
+			for j, i := range g.idx {
+
+				switch {
+				case unique && found[j]:
+					errs[i] = NewFuncError("already exists", "source", g.req.Source)
+
+				case !unique && !found[j]:
+					errs[i] = NewFuncError("does not exist", "source", g.req.Source)
+				}
+			}
+		}
+
+		return errs, nil
+	}
+}
+
+// MemoryLookup is an in-memory LookupProvider intended for use in tests, whose sources are the columns of tables of
+// records eg. 'users.email', the 'id' column of a table being the IDs of its records.
+type MemoryLookup struct {
+	mu      sync.RWMutex
+	tables  map[string][]memoryRecord
+	lookups int
+}
+
+type memoryRecord struct {
+	id      interface{}
+	columns map[string]interface{}
+}
+
+var _ LookupProvider = new(MemoryLookup)
+
+// NewMemoryLookup returns a new, empty, MemoryLookup.
+func NewMemoryLookup() *MemoryLookup {
+	return &MemoryLookup{tables: make(map[string][]memoryRecord)}
+}
+
+// Add adds the record with the given ID and column values to the table eg.
+//
+//	lookup.Add("users", 1, map[string]interface{}{"email": "joey@example.com"})
+//
+// Values are compared using reflect.DeepEqual so they must be of the same type as the fields being validated.
+func (m *MemoryLookup) Add(table string, id interface{}, columns map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tables[table] = append(m.tables[table], memoryRecord{id: id, columns: columns})
+}
+
+// Lookups returns the number of times Lookup has been called, which can be used to check that lookups are batched.
+func (m *MemoryLookup) Lookups() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.lookups
+}
+
+// Lookup returns whether each of the values exists within the column of the table given by the request's source.
+func (m *MemoryLookup) Lookup(ctx context.Context, req LookupRequest) ([]bool, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	idx := strings.LastIndex(req.Source, namespaceSeparator)
+	if idx == -1 {
+		return nil, fmt.Errorf("validator: invalid lookup source '%s', must be a table and column eg. users.id", req.Source)
+	}
+
+	table, column := req.Source[:idx], req.Source[idx+1:]
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lookups++
+
+	found := make([]bool, len(req.Values))
+
+	for i, value := range req.Values {
+		for _, rec := range m.tables[table] {
+
+			if req.Exclude != nil && reflect.DeepEqual(rec.id, req.Exclude) {
+				continue
+			}
+
+			v, ok := rec.columns[column]
+			if column == "id" {
+				v, ok = rec.id, true
+			}
+
+			if ok && reflect.DeepEqual(v, value) {
+				found[i] = true
+				break
+			}
+		}
+	}
+
+	return found, nil
+}
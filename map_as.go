@@ -0,0 +1,488 @@
+package validator
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// ValidateMapAs validates map data, such as decoded from JSON, YAML or a message
+// queue payload, using the validation rules of the struct type of exampleType,
+// which can be a struct or a pointer to one eg. User{} or (*User)(nil).
+//
+// The keys of the map are matched to the struct's fields using the names from the
+// function registered using RegisterTagNameFunc, or the Go names when none is,
+// and the values are assigned to a new value of the struct type using reflection,
+// without a round trip through an encoding, so that all of the validations
+// including dive, cross-field and struct level validations apply as they do when
+// using Struct. Strings are assigned to types implementing encoding.TextUnmarshaler
+// such as time.Time.
+//
+// Keys missing from the map, or whose values are nil, are seen as missing values
+// instead of as the zero values of their fields, so that eg. 'required' fails for
+// a missing key but not for a key whose value is 0 or "". Only the 'required' and
+// conditional 'required_*' validations, such as 'required_if', apply to missing
+// values, the others being skipped the same as for fields using 'omitempty',
+// including those of the elements after 'dive'; the struct value is only used as
+// the parent of cross-field and struct level validations.
+//
+// Values which can't be assigned to their field are reported using the 'type' tag,
+// with the field's type as the param, instead of the field's own validations. Keys
+// without a matching field are ignored, unless using WithStrictMaps in which case
+// they are reported using the 'unknown_key' tag.
+//
+// It returns InvalidValidationError for bad example types passed in and nil or
+// ValidationErrors as error otherwise.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
+func (v *Validate) ValidateMapAs(ctx context.Context, data map[string]interface{}, exampleType interface{}) (err error) {
+
+	typ := reflect.TypeOf(exampleType)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct || typ.ConvertibleTo(timeType) {
+		return &InvalidValidationError{Type: reflect.TypeOf(exampleType)}
+	}
+
+	val := reflect.New(typ).Elem()
+
+	// good to validate
+	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
+	vd.top = val
+	vd.isPartial = false
+	vd.missing = make(map[string]struct{})
+
+	cs, ok := v.structCache.Get(typ)
+	if !ok {
+		cs = v.extractStructCache(val, typ.Name())
+	}
+
+	ns, structNs := vd.appendRoot(vd.ns[0:0], vd.actualNs[0:0], cs)
+	vd.decodeStruct(val, cs, data, ns, structNs, nil)
+
+	// the field's own validations are skipped for values that couldn't be assigned
+	decodeErrs := len(vd.errs)
+
+	vd.validateStruct(ctx, val, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil, nil)
+	err = vd.runAsync(ctx)
+
+	if decodeErrs > 0 {
+		vd.errs = filterDecodeErrors(vd.errs, decodeErrs)
+	}
+
+	if err == nil && len(vd.errs) > 0 {
+		err = vd.errs
+	}
+	vd.errs = nil
+	vd.warns = nil
+	vd.missing = nil
+
+	v.pool.Put(vd)
+
+	return
+}
+
+// decodeStruct assigns the values of the map to the fields of the struct, recording
+// the fields whose keys are missing, used is non nil when decoding the fields of an
+// inlined embedded struct in which case the keys are marked as used for the parent
+// to report the unknown keys. cs is the struct's cStruct when already known eg. the
+// nested cStruct of an inlined embedded struct, see cField.nested.
+func (v *validate) decodeStruct(current reflect.Value, cs *cStruct, data map[string]interface{}, ns []byte, structNs []byte, used map[string]struct{}) {
+
+	if cs == nil {
+		var ok bool
+
+		typ := current.Type()
+
+		cs, ok = v.v.structCache.Get(typ)
+		if !ok {
+			cs = v.v.extractStructCache(current, typ.Name())
+		}
+	}
+
+	isTop := used == nil
+	if isTop && v.v.strictMaps {
+		used = make(map[string]struct{}, len(data))
+	}
+
+	var f *cField
+
+	for i := 0; i < len(cs.fields); i++ {
+
+		f = cs.fields[i]
+		fv := current.Field(f.idx)
+
+		// fields only kept for their modifiers aren't validated, see Normalize
+		if f.cTags == nil || !fv.CanSet() {
+			continue
+		}
+
+		// the fields of inlined embedded structs are promoted into the parent, see WithJSONFieldNames
+		if f.inline {
+
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+
+			if used == nil {
+				used = make(map[string]struct{}, len(data))
+			}
+
+			v.decodeStruct(fv, f.nested, data, ns, append(append(structNs, f.name...), '.'), used)
+			continue
+		}
+
+		src, ok := data[f.altName]
+		if !ok || src == nil {
+			v.missing[string(append(structNs, f.name...))] = struct{}{}
+			continue
+		}
+
+		if used != nil {
+			used[f.altName] = struct{}{}
+		}
+
+		v.decodeValue(fv, src, ns, structNs, f.altName, f.name)
+	}
+
+	if isTop && v.v.strictMaps {
+
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			if _, ok := used[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			v.reportDecode(ns, structNs, k, k, unknownKeyTag, "", data[k])
+		}
+	}
+}
+
+// decodeValue assigns src to current, reporting values which can't be assigned
+// using the 'type' tag.
+func (v *validate) decodeValue(current reflect.Value, src interface{}, ns []byte, structNs []byte, name, structName string) {
+
+	if src == nil {
+		return
+	}
+
+	if current.Kind() == reflect.Ptr {
+
+		if current.IsNil() {
+			current.Set(reflect.New(current.Type().Elem()))
+		}
+
+		v.decodeValue(current.Elem(), src, ns, structNs, name, structName)
+		return
+	}
+
+	if s, ok := src.(string); ok && reflect.PtrTo(current.Type()).Implements(textUnmarshalerType) {
+
+		if err := current.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			v.reportDecode(ns, structNs, name, structName, typeTag, current.Type().String(), src)
+		}
+		return
+	}
+
+	sv := reflect.ValueOf(src)
+
+	if sv.Type().AssignableTo(current.Type()) {
+		current.Set(sv)
+		return
+	}
+
+	var ok bool
+
+	switch current.Kind() {
+	case reflect.Struct:
+
+		var m map[string]interface{}
+		if m, ok = src.(map[string]interface{}); ok {
+			v.decodeStruct(current, nil, m, append(append(ns, name...), '.'), append(append(structNs, structName...), '.'), nil)
+		}
+
+	case reflect.Slice, reflect.Array:
+
+		if ok = sv.Kind() == reflect.Slice || sv.Kind() == reflect.Array; !ok {
+			break
+		}
+
+		if current.Kind() == reflect.Slice {
+			current.Set(reflect.MakeSlice(current.Type(), sv.Len(), sv.Len()))
+		} else if ok = sv.Len() == current.Len(); !ok {
+			break
+		}
+
+		for i := 0; i < sv.Len(); i++ {
+			idx := "[" + strconv.Itoa(i) + "]"
+			v.decodeValue(current.Index(i), sv.Index(i).Interface(), ns, structNs, name+idx, structName+idx)
+		}
+
+	case reflect.Map:
+
+		if ok = sv.Kind() == reflect.Map; !ok {
+			break
+		}
+
+		mt := current.Type()
+		current.Set(reflect.MakeMapWithSize(mt, sv.Len()))
+
+		iter := sv.MapRange()
+		for iter.Next() {
+
+			idx := "[" + fmt.Sprintf("%v", iter.Key().Interface()) + "]"
+
+			key := reflect.New(mt.Key()).Elem()
+			if !decodeKey(key, iter.Key().Interface()) {
+				v.reportDecode(ns, structNs, name+idx, structName+idx, typeTag, mt.Key().String(), iter.Key().Interface())
+				continue
+			}
+
+			elem := reflect.New(mt.Elem()).Elem()
+			v.decodeValue(elem, iter.Value().Interface(), ns, structNs, name+idx, structName+idx)
+			current.SetMapIndex(key, elem)
+		}
+
+	default:
+		ok = decodeScalar(current, sv)
+	}
+
+	if !ok {
+		v.reportDecode(ns, structNs, name, structName, typeTag, current.Type().String(), src)
+	}
+}
+
+// decodeKey assigns a map key, map keys in decoded data are usually strings and
+// so are also parsed into numeric and encoding.TextUnmarshaler keys.
+func decodeKey(current reflect.Value, src interface{}) bool {
+
+	if s, ok := src.(string); ok {
+
+		if reflect.PtrTo(current.Type()).Implements(textUnmarshalerType) {
+			return current.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)) == nil
+		}
+
+		switch current.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			src = json.Number(s)
+		}
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(current.Type()) {
+		current.Set(sv)
+		return true
+	}
+
+	return decodeScalar(current, sv)
+}
+
+// decodeScalar assigns numbers, strings and booleans to values of the same kind,
+// including named types, checking numbers fit without loss.
+func decodeScalar(current reflect.Value, sv reflect.Value) bool {
+
+	if n, ok := sv.Interface().(json.Number); ok {
+		return decodeNumber(current, string(n))
+	}
+
+	switch current.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		var i int64
+
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = sv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if sv.Uint() > math.MaxInt64 {
+				return false
+			}
+			i = int64(sv.Uint())
+		case reflect.Float32, reflect.Float64:
+			f := sv.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return false
+			}
+			i = int64(f)
+		default:
+			return false
+		}
+
+		if current.OverflowInt(i) {
+			return false
+		}
+		current.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+
+		var u uint64
+
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if sv.Int() < 0 {
+				return false
+			}
+			u = uint64(sv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u = sv.Uint()
+		case reflect.Float32, reflect.Float64:
+			f := sv.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				return false
+			}
+			u = uint64(f)
+		default:
+			return false
+		}
+
+		if current.OverflowUint(u) {
+			return false
+		}
+		current.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+
+		var f float64
+
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(sv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			f = float64(sv.Uint())
+		case reflect.Float32, reflect.Float64:
+			f = sv.Float()
+		default:
+			return false
+		}
+
+		if current.OverflowFloat(f) {
+			return false
+		}
+		current.SetFloat(f)
+
+	case reflect.String, reflect.Bool:
+
+		if sv.Kind() != current.Kind() {
+			return false
+		}
+		current.Set(sv.Convert(current.Type()))
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// decodeNumber assigns a json.Number, or a numeric map key, to numeric kinds.
+func decodeNumber(current reflect.Value, n string) bool {
+
+	switch current.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(n, 10, 64)
+		if err != nil || current.OverflowInt(i) {
+			return false
+		}
+		current.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(n, 10, 64)
+		if err != nil || current.OverflowUint(u) {
+			return false
+		}
+		current.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil || current.OverflowFloat(f) {
+			return false
+		}
+		current.SetFloat(f)
+
+	case reflect.String:
+		current.SetString(n)
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// reportDecode reports a value of the map data which couldn't be assigned, or a
+// key without a field, see ValidateMapAs.
+func (v *validate) reportDecode(ns []byte, structNs []byte, name, structName, tag, param string, value interface{}) {
+
+	val := reflect.ValueOf(value)
+
+	fe := &fieldError{
+		v:              v.v,
+		tag:            tag,
+		actualTag:      tag,
+		ns:             string(append(ns, name...)),
+		structNs:       string(append(structNs, structName...)),
+		fieldLen:       uint8(len(name)),
+		structfieldLen: uint8(len(structName)),
+		value:          value,
+		param:          param,
+		kind:           val.Kind(),
+	}
+
+	if val.IsValid() {
+		fe.typ = val.Type()
+	}
+
+	v.report(fe, nil, false)
+}
+
+// filterDecodeErrors removes the validation errors of the fields, and their
+// nested fields, whose values couldn't be assigned; the first n errors are
+// those reported while assigning the values.
+func filterDecodeErrors(errs ValidationErrors, n int) ValidationErrors {
+
+	filtered := errs[:n]
+
+	for _, fe := range errs[n:] {
+
+		structNs := fe.StructNamespace()
+		skip := false
+
+		for i := 0; i < n; i++ {
+
+			if errs[i].Tag() != typeTag {
+				continue
+			}
+
+			p := errs[i].StructNamespace()
+			if structNs == p || (strings.HasPrefix(structNs, p) && (structNs[len(p)] == '.' || structNs[len(p)] == '[')) {
+				skip = true
+				break
+			}
+		}
+
+		if !skip {
+			filtered = append(filtered, fe)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,552 @@
+package validator
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ModifierFunc modifies the value of a field in place, see RegisterModifier.
+// The value returned by FieldLevel.Field() can always be set.
+type ModifierFunc func(ctx context.Context, fl FieldLevel)
+
+var bakedInModifiers = map[string]ModifierFunc{
+	"trim":          trimModifier,
+	"ltrim":         ltrimModifier,
+	"rtrim":         rtrimModifier,
+	"lower":         lowerModifier,
+	"upper":         upperModifier,
+	"title":         titleModifier,
+	"collapse_ws":   collapseWSModifier,
+	"nfc":           nfcModifier,
+	defaultModifier: defaultValueModifier,
+}
+
+// RegisterModifier adds a modifier with the given tag, which is run on the
+// fields using the tag within their 'mod' tag, validation tag or map rules by
+// Normalize, replacing any modifier previously registered with the tag,
+// including the baked in ones. Validations registered with the same tag take
+// precedence over the modifier outside of the 'mod' tag.
+//
+//	validate.RegisterModifier("digits", func(ctx context.Context, fl validator.FieldLevel) {
+//	    fl.Field().SetString(strings.Map(func(r rune) rune {
+//	        if unicode.IsDigit(r) {
+//	            return r
+//	        }
+//	        return -1
+//	    }, fl.Field().String()))
+//	})
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterModifier(tag string, fn ModifierFunc) error {
+
+	if len(tag) == 0 {
+		return errors.New("function Key cannot be empty")
+	}
+
+	if fn == nil {
+		return errors.New("function cannot be empty")
+	}
+
+	if _, ok := restrictedTags[tag]; ok || strings.ContainsAny(tag, restrictedTagChars) {
+		panic(fmt.Sprintf(restrictedTagErr, tag))
+	}
+
+	v.modifiers[tag] = fn
+
+	return nil
+}
+
+// Normalize modifies the fields of the struct which s must be a pointer to,
+// running the modifiers of their 'mod' tags in order, such as trimming or
+// lowercasing strings, and setting the value of their 'default' tags when they
+// are zero. Nested structs are normalized as well and the elements of slices,
+// arrays and maps, or the keys of maps, using the dive, keys and endkeys tags
+// the same as when validating:
+//
+//	type User struct {
+//	    Email string   `mod:"trim,lower" validate:"required,email"`
+//	    Tags  []string `mod:"dive,trim"`
+//	    Limit int      `default:"10"`
+//	}
+//
+// Modifiers within the validation tag, or the map rules of the struct, replace
+// those of the 'mod' tag, see RegisterStructValidationMapRules.
+//
+// It returns InvalidValidationError when s isn't a non-nil pointer to a struct,
+// and KeyCollisionError when modifying the keys of a map results in two of its
+// keys being the same, instead of one of their elements being overwritten.
+func (v *Validate) Normalize(ctx context.Context, s interface{}) error {
+
+	val := reflect.ValueOf(s)
+
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct || val.Elem().Type().ConvertibleTo(timeType) {
+		return &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
+	vd.top = val
+	vd.isPartial = false
+	vd.modifying = true
+
+	vd.validateStruct(ctx, val, val.Elem(), val.Elem().Type(), vd.ns[0:0], vd.actualNs[0:0], nil, nil)
+
+	err := vd.abort
+	vd.abort = nil
+	vd.modifying = false
+
+	v.pool.Put(vd)
+
+	return err
+}
+
+// NormalizeAndValidate normalizes the struct which s must be a pointer to, see
+// Normalize, and then validates it, see StructCtx.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
+func (v *Validate) NormalizeAndValidate(ctx context.Context, s interface{}) error {
+
+	if err := v.Normalize(ctx, s); err != nil {
+		return err
+	}
+
+	return v.StructCtx(ctx, s)
+}
+
+// parseModTags returns the modifiers of the field, those of its 'default' tag
+// followed by those of the tag, nil when the field can't be normalized, or an
+// empty cTag for traversing its nested structs when it has no modifiers.
+func (v *Validate) parseModTags(fld reflect.StructField, tag string) *cTag {
+
+	// unexported fields can't be set
+	if (!fld.Anonymous && len(fld.PkgPath) > 0) || tag == skipValidationTag {
+		return nil
+	}
+
+	var ctag, last *cTag
+
+	if value, ok := fld.Tag.Lookup(defaultModifier); ok {
+		ctag = &cTag{tag: defaultModifier, aliasTag: defaultModifier, param: value, hasParam: true, hasTag: true, mod: v.modifiers[defaultModifier]}
+		last = ctag
+	}
+
+	if len(tag) > 0 {
+		first, _ := v.parseTagsRecursive(tag, fld.Name, "", false, true)
+		if last == nil {
+			ctag = first
+		} else {
+			last.next = first
+		}
+	}
+
+	if ctag == nil {
+		ctag = new(cTag)
+	}
+
+	return ctag
+}
+
+// splitModifiers splits the modifiers out of the validation tag, or map rule,
+// the dive, keys and endkeys tags being kept by both eg. 'dive,trim,email' is
+// split into 'dive,email' and 'dive,trim'. The modifiers are empty when the tag
+// has none, in which case the tag is returned as is.
+func (v *Validate) splitModifiers(tag string) (string, string) {
+
+	if len(tag) == 0 || tag == skipValidationTag {
+		return tag, ""
+	}
+
+	tags := strings.Split(tag, tagSeparator)
+	vals := make([]string, 0, len(tags))
+	mods := make([]string, 0, len(tags))
+
+	for _, t := range tags {
+		switch {
+		case t == diveTag || t == keysTag || t == endKeysTag:
+			vals = appendSplitTag(vals, t)
+			mods = appendSplitTag(mods, t)
+
+		case v.isModifier(t):
+			mods = append(mods, t)
+
+		default:
+			vals = append(vals, t)
+		}
+	}
+
+	if len(vals) == len(tags) {
+		return tag, ""
+	}
+
+	return strings.Join(trimDives(vals), tagSeparator), strings.Join(trimDives(mods), tagSeparator)
+}
+
+// isModifier returns if the tag is a registered modifier, validations and
+// aliases taking precedence over modifiers with the same tag.
+func (v *Validate) isModifier(t string) bool {
+
+	name, _, _ := strings.Cut(t, tagKeySeparator)
+
+	if _, ok := v.modifiers[name]; !ok {
+		return false
+	}
+
+	if _, ok := v.validations[name]; ok {
+		return false
+	}
+
+	_, ok := v.aliases[name]
+	return !ok
+}
+
+// appendSplitTag appends the dive, keys or endkeys tag to the split tags,
+// dropping the keys block instead when it's left empty by the split.
+func appendSplitTag(tags []string, t string) []string {
+
+	if t == endKeysTag && len(tags) > 0 && tags[len(tags)-1] == keysTag {
+		return tags[:len(tags)-1]
+	}
+
+	return append(tags, t)
+}
+
+// trimDives removes the trailing dive tags left without anything to run on the
+// elements once the tags have been split.
+func trimDives(tags []string) []string {
+
+	for len(tags) > 0 && tags[len(tags)-1] == diveTag {
+		tags = tags[:len(tags)-1]
+	}
+
+	return tags
+}
+
+// diveTags returns the dive tags of the validation tag, without those of its
+// keys blocks, which the elements are dived into with.
+func diveTags(tag string) string {
+
+	var dives []string
+	var inKeys bool
+
+	for _, t := range strings.Split(tag, tagSeparator) {
+		switch t {
+		case keysTag:
+			inKeys = true
+
+		case endKeysTag:
+			inKeys = false
+
+		case diveTag:
+			if !inKeys {
+				dives = append(dives, t)
+			}
+		}
+	}
+
+	return strings.Join(dives, tagSeparator)
+}
+
+// hasNestedStruct returns if the value of the type may be a struct, or contain
+// one, whose fields need normalizing.
+func hasNestedStruct(typ reflect.Type) bool {
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ.Kind() == reflect.Interface || typ.Kind() == reflect.Struct && !isValueStruct(typ)
+}
+
+// parseModifier returns the cTag of one of the modifiers of a 'mod' tag eg. 'trim=/'.
+func (v *Validate) parseModifier(t string, fieldName string) *cTag {
+
+	vals := strings.SplitN(t, tagKeySeparator, 2)
+
+	if len(vals[0]) == 0 {
+		panic(fmt.Sprintf(invalidModifier, fieldName))
+	}
+
+	fn, ok := v.modifiers[vals[0]]
+	if !ok {
+		panic(fmt.Sprintf(undefinedModifier, vals[0], fieldName))
+	}
+
+	ct := &cTag{tag: vals[0], aliasTag: vals[0], hasTag: true, mod: fn}
+
+	if len(vals) > 1 {
+		ct.hasParam = true
+		ct.param = strings.Replace(strings.Replace(vals[1], utf8HexComma, ",", -1), utf8Pipe, "|", -1)
+	}
+
+	return ct
+}
+
+// modifyInterface modifies a copy of the value of the interface, which can't
+// be set, setting the copy back into the interface.
+func (v *validate) modifyInterface(ctx context.Context, parent reflect.Value, current reflect.Value, ns []byte, structNs []byte, cf *cField, ct *cTag) {
+
+	if current.IsNil() || !current.CanSet() {
+		return
+	}
+
+	elem := reflect.New(current.Elem().Type()).Elem()
+	elem.Set(current.Elem())
+
+	v.traverseField(ctx, parent, elem, ns, structNs, cf, ct)
+
+	current.Set(elem)
+}
+
+// allocDefaults allocates the nil pointers of the field when its modifiers
+// before any dive include a default, there being nothing to modify otherwise.
+func allocDefaults(current reflect.Value, ct *cTag) {
+
+	for ; ct != nil && ct.typeof != typeDive; ct = ct.next {
+
+		if ct.tag != defaultModifier {
+			continue
+		}
+
+		for current.Kind() == reflect.Ptr {
+
+			if current.IsNil() {
+				if !current.CanSet() {
+					return
+				}
+				current.Set(reflect.New(current.Type().Elem()))
+			}
+
+			current = current.Elem()
+		}
+
+		return
+	}
+}
+
+// modifyMap runs the modifiers on the elements of the map, and on its keys when
+// using the keys tag. The elements of maps can't be set, so copies of them are
+// modified and set back into the map, along with the modified keys once all of
+// them have been modified, failing with a KeyCollisionError, and leaving the map
+// as is, when two of the keys are modified to the same key.
+func (v *validate) modifyMap(ctx context.Context, parent reflect.Value, current reflect.Value, ns []byte, structNs []byte, cf *cField, ct *cTag) {
+
+	// maps of unexported fields can't be set
+	if current.IsNil() || !current.CanInterface() {
+		return
+	}
+
+	var keysCt *cTag
+
+	if ct != nil && ct.typeof == typeKeys {
+		keysCt = ct.keys
+		ct = ct.next
+	}
+
+	typ := current.Type()
+	keys := current.MapKeys()
+	elems := make([]reflect.Value, len(keys))
+	newKeys := keys
+	reusableCF := &cField{}
+
+	var modified map[interface{}]reflect.Value
+
+	if keysCt != nil {
+		newKeys = make([]reflect.Value, len(keys))
+		modified = make(map[interface{}]reflect.Value, len(keys))
+	}
+
+	for i, key := range keys {
+
+		reusableCF.name = fmt.Sprintf("%s[%v]", cf.name, key.Interface())
+		reusableCF.altName = reusableCF.name
+
+		elems[i] = reflect.New(typ.Elem()).Elem()
+		elems[i].Set(current.MapIndex(key))
+
+		if keysCt == nil {
+			reusableCF.nested = cf.nested
+			v.traverseField(ctx, parent, elems[i], ns, structNs, reusableCF, ct)
+			continue
+		}
+
+		newKeys[i] = reflect.New(typ.Key()).Elem()
+		newKeys[i].Set(key)
+
+		// rule paths only apply to the map's values
+		reusableCF.nested = nil
+		v.traverseField(ctx, parent, newKeys[i], ns, structNs, reusableCF, keysCt)
+
+		if other, ok := modified[newKeys[i].Interface()]; ok {
+			v.abort = newKeyCollisionError(string(append(structNs, cf.name...)), other.Interface(), key.Interface(), newKeys[i].Interface())
+			return
+		}
+
+		modified[newKeys[i].Interface()] = key
+
+		if ct != nil {
+			reusableCF.nested = cf.nested
+			v.traverseField(ctx, parent, elems[i], ns, structNs, reusableCF, ct)
+		}
+	}
+
+	if keysCt != nil {
+		for _, key := range keys {
+			current.SetMapIndex(key, reflect.Value{})
+		}
+	}
+
+	for i := range keys {
+		current.SetMapIndex(newKeys[i], elems[i])
+	}
+}
+
+// stringField returns the field's value, panicking if it isn't a string.
+func stringField(fl FieldLevel) string {
+
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+
+	return field.String()
+}
+
+// trimModifier removes the leading and trailing white space of the field, or
+// the characters of the param when it has one eg. trim=/
+func trimModifier(_ context.Context, fl FieldLevel) {
+
+	if len(fl.Param()) > 0 {
+		fl.Field().SetString(strings.Trim(stringField(fl), fl.Param()))
+		return
+	}
+
+	fl.Field().SetString(strings.TrimSpace(stringField(fl)))
+}
+
+// ltrimModifier removes the leading white space of the field, or the
+// characters of the param when it has one.
+func ltrimModifier(_ context.Context, fl FieldLevel) {
+
+	if len(fl.Param()) > 0 {
+		fl.Field().SetString(strings.TrimLeft(stringField(fl), fl.Param()))
+		return
+	}
+
+	fl.Field().SetString(strings.TrimLeftFunc(stringField(fl), unicode.IsSpace))
+}
+
+// rtrimModifier removes the trailing white space of the field, or the
+// characters of the param when it has one.
+func rtrimModifier(_ context.Context, fl FieldLevel) {
+
+	if len(fl.Param()) > 0 {
+		fl.Field().SetString(strings.TrimRight(stringField(fl), fl.Param()))
+		return
+	}
+
+	fl.Field().SetString(strings.TrimRightFunc(stringField(fl), unicode.IsSpace))
+}
+
+func lowerModifier(_ context.Context, fl FieldLevel) {
+	fl.Field().SetString(strings.ToLower(stringField(fl)))
+}
+
+func upperModifier(_ context.Context, fl FieldLevel) {
+	fl.Field().SetString(strings.ToUpper(stringField(fl)))
+}
+
+// titleModifier capitalizes the first letter of each word of the field and
+// lowercases the rest.
+func titleModifier(_ context.Context, fl FieldLevel) {
+	fl.Field().SetString(cases.Title(language.Und).String(stringField(fl)))
+}
+
+// collapseWSModifier replaces each run of white space within the field with a
+// single space.
+func collapseWSModifier(_ context.Context, fl FieldLevel) {
+
+	s := stringField(fl)
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	space := false
+
+	for _, r := range s {
+
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	if space {
+		b.WriteByte(' ')
+	}
+
+	fl.Field().SetString(b.String())
+}
+
+// nfcModifier normalizes the field to Unicode Normalization Form C, so that
+// equal strings have the same representation.
+func nfcModifier(_ context.Context, fl FieldLevel) {
+	fl.Field().SetString(norm.NFC.String(stringField(fl)))
+}
+
+// defaultValueModifier sets the field to the param when it's zero, which is
+// parsed according to the field's type, using UnmarshalText for types which
+// implement encoding.TextUnmarshaler such as time.Time.
+func defaultValueModifier(_ context.Context, fl FieldLevel) {
+
+	field := fl.Field()
+	param := fl.Param()
+
+	if !isZero(field) {
+		return
+	}
+
+	if reflect.PtrTo(field.Type()).Implements(textUnmarshalerType) {
+		panicIf(field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(param)))
+		return
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(param)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(asIntFromType(field.Type(), param))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		field.SetUint(asUint(param))
+
+	case reflect.Float32:
+		field.SetFloat(asFloat32(param))
+
+	case reflect.Float64:
+		field.SetFloat(asFloat64(param))
+
+	case reflect.Bool:
+		field.SetBool(asBool(param))
+
+	default:
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+}
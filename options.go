@@ -1,5 +1,10 @@
 package validator
 
+import (
+	"database/sql"
+	"time"
+)
+
 // Option represents a configurations option to be applied to validator during initialization.
 type Option func(*Validate)
 
@@ -24,3 +29,125 @@ func WithPrivateFieldValidation() Option {
 		v.privateFieldValidation = true
 	}
 }
+
+// WithAllFieldErrors makes validation continue with the remaining validations on a field after one fails so that
+// every failing validation on the field is reported instead of only the first.
+//
+// The remaining validations are still skipped when one of the required tags fails, and a field's nested struct or
+// dive validations are only run if all of the field's own validations pass. The same behaviour can be enabled for
+// individual fields using the 'allerrors' tag.
+func WithAllFieldErrors() Option {
+	return func(v *Validate) {
+		v.allFieldErrors = true
+	}
+}
+
+// WithSortedMapKeys makes validation dive into maps in the sorted order of their keys, instead of Go's randomized map
+// iteration order, so that the same input always results in the same ValidationErrors in the same order.
+//
+// Keys of ordered kinds such as integers, floats and strings are sorted by their value and all other keys by their
+// fmt %v representation. Struct fields are always validated in the order they are declared in, see ValidationErrors.Sort
+// for ordering errors by their namespace.
+func WithSortedMapKeys() Option {
+	return func(v *Validate) {
+		v.sortedMapKeys = true
+	}
+}
+
+// WithJSONFieldNames makes the FieldError's use the names of the fields in their encoding/json representation,
+// replacing any function registered using RegisterTagNameFunc, following the same rules as encoding/json:
+//
+//   - the name from the json tag, eg. `json:"first_name,omitempty"`
+//   - the field's Go name when the tag has no name or the name isn't valid, eg. `json:",omitempty"`
+//   - fields skipped using `json:"-"` aren't validated, the same as using `validate:"-"`
+//   - the fields of embedded structs without a name in their tag are promoted into the parent's namespace
+//     eg. 'User.id' instead of 'User.Base.id'
+//   - promoted fields are hidden by fields of the same name at a shallower depth, or by tagged fields at the same
+//     depth, and are dropped along with the fields they conflict with when none of them is dominant, the hidden
+//     fields not being validated
+//
+// The struct namespace is unaffected and always uses the fields' Go names.
+func WithJSONFieldNames() Option {
+	return func(v *Validate) {
+		v.RegisterTagNameFunc(jsonFieldName)
+		v.jsonFieldNames = true
+	}
+}
+
+// WithStrictMaps makes ValidateMapErr report the keys of the data which have no rules, using the 'unknown_key' tag, and
+// the nested objects which have rules but are missing from the data, using the 'required' tag; both are otherwise
+// ignored.
+func WithStrictMaps() Option {
+	return func(v *Validate) {
+		v.strictMaps = true
+	}
+}
+
+// WithValueRedactor sets the function used to redact the value of a FieldError for sensitive fields and validations,
+// its return value replaces the field's value in the FieldError. By default the value is replaced with nil.
+//
+// Fields are marked as sensitive using the 'sensitive' tag and validations using RegisterSensitiveTags or
+// WithSensitiveTagRedaction.
+func WithValueRedactor(fn func(fe FieldError) interface{}) Option {
+	return func(v *Validate) {
+		v.valueRedactor = fn
+	}
+}
+
+// WithSensitiveTagRedaction marks the baked in validations which are commonly used for sensitive values, such as
+// credit_card, jwt and ssn, as sensitive so that the value of the field is redacted from the FieldError when they fail.
+//
+// This was made opt-in behaviour in order to maintain backward compatibility with the values previously reported.
+func WithSensitiveTagRedaction() Option {
+	return func(v *Validate) {
+		v.RegisterSensitiveTags(bakedInSensitiveTags...)
+	}
+}
+
+// WithStructLevelBeforeFields makes struct level validations run before the validations of the struct's fields,
+// instead of after them.
+func WithStructLevelBeforeFields() Option {
+	return func(v *Validate) {
+		v.structLevelBeforeFields = true
+	}
+}
+
+// WithStructLevelSkipOnFieldErrors makes struct level validations only run when all of the validations of the
+// struct's fields, including those of the structs nested within them, have passed, so that they don't need to handle
+// invalid data. Warnings don't prevent them from running.
+func WithStructLevelSkipOnFieldErrors() Option {
+	return func(v *Validate) {
+		v.structLevelSkipOnFieldErrors = true
+	}
+}
+
+// WithClock makes the validations of times, such as the 'gt' validation of a time.Time and the 'within' validation,
+// use the current time returned by now instead of time.Now, so that they can be tested deterministically. It can also
+// be overridden for a single call using ContextWithClock.
+func WithClock(now func() time.Time) Option {
+	return func(v *Validate) {
+		v.clock = now
+	}
+}
+
+// WithAsyncWorkers sets the maximum number of asynchronous validations, registered using RegisterAsyncValidation,
+// which are run concurrently by each call, which is 8 by default.
+func WithAsyncWorkers(n int) Option {
+	return func(v *Validate) {
+		v.asyncWorkers = n
+	}
+}
+
+// WithSQLNullTypes registers a CustomTypeFunc for the database/sql Null types, such as sql.NullString and sql.NullTime,
+// so that they're validated as the value they hold when valid and as nil otherwise eg. `validate:"required,max=32"`
+// on a sql.NullString field requires it to be valid and its string to be at most 32 characters.
+//
+// The generic sql.Null[T] can be handled using RegisterCustomTypeFuncByInterface with driver.Valuer.
+func WithSQLNullTypes() Option {
+	return func(v *Validate) {
+		v.RegisterCustomTypeFunc(sqlNullValue,
+			sql.NullString{}, sql.NullInt64{}, sql.NullInt32{}, sql.NullInt16{}, sql.NullByte{},
+			sql.NullFloat64{}, sql.NullBool{}, sql.NullTime{},
+		)
+	}
+}
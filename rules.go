@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"unsafe"
+)
+
+// RuleBuilder registers the validation rules of a struct type in code, as an
+// alternative to struct tags, see Rules.
+type RuleBuilder[T any] struct {
+	v   *Validate
+	typ reflect.Type
+}
+
+// Rules returns a RuleBuilder for registering the validation rules of the struct
+// type T, which are compiled the same as struct tags, so that rules can be added
+// to types which you don't own and the fields are referenced in code instead of
+// by name.
+//
+//	validator.Rules[User](validate).
+//	    Field(func(u *User) interface{} { return &u.Name }, "required,max=64").
+//	    Field(func(u *User) interface{} { return &u.Address.City }, "required").
+//	    Struct(func(sl validator.StructLevel, u User) {
+//	        if u.Name == u.Address.City {
+//	            sl.ReportError(u.Name, "Name", "Name", "nequalcity", "")
+//	        }
+//	    })
+//
+// The rules are registered the same as using RegisterStructValidationMapRules and
+// supersede those defined on the struct, including its modifiers, see Normalize.
+//
+// NOTE: this is not thread-safe it is intended that these all be registered prior to any validation
+func Rules[T any](v *Validate) *RuleBuilder[T] {
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: Rules requires a struct type, got '%s'", typ))
+	}
+
+	return &RuleBuilder[T]{v: v, typ: typ}
+}
+
+// Field registers the validation tag for the field which the function returns a
+// pointer to eg. func(u *User) interface{} { return &u.Name }. The fields of
+// nested structs which aren't pointers can be selected as well eg. &u.Address.City.
+//
+// It panics if the returned value isn't a pointer to a field of the struct.
+func (b *RuleBuilder[T]) Field(field func(t *T) interface{}, tag string) *RuleBuilder[T] {
+
+	path := b.fieldPath(field)
+
+	// the rules may be shared with other types, see RegisterStructValidationMapRules
+	rules := make(map[string]string, len(b.v.rules[b.typ])+1)
+	for p, rule := range b.v.rules[b.typ] {
+		rules[p] = rule
+	}
+	rules[path] = tag
+
+	if b.v.rules == nil {
+		b.v.rules = make(map[reflect.Type]map[string]string)
+	}
+	b.v.rules[b.typ] = rules
+
+	return b
+}
+
+// Struct registers the struct level validation for the type, replacing any
+// previously registered for it, see RegisterStructValidationFor.
+func (b *RuleBuilder[T]) Struct(fn func(sl StructLevel, t T)) *RuleBuilder[T] {
+
+	RegisterStructValidationFor(b.v, func(_ context.Context, sl StructLevel, t T) {
+		fn(sl, t)
+	})
+
+	return b
+}
+
+// fieldPath returns the path of the field which the function returns a pointer
+// to, as used by RegisterStructValidationMapRules.
+func (b *RuleBuilder[T]) fieldPath(field func(t *T) interface{}) string {
+
+	t := new(T)
+	ptr := reflect.ValueOf(field(t))
+
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		panic(fmt.Sprintf("validator: field of '%s' must be selected using a pointer eg. &t.Field", b.typ))
+	}
+
+	base := uintptr(unsafe.Pointer(t))
+	addr := ptr.Pointer()
+
+	var path string
+	var ok bool
+
+	if addr == base || (addr > base && addr-base < b.typ.Size()) {
+		path, ok = findFieldPath(b.typ, addr-base, ptr.Type().Elem())
+	}
+
+	runtime.KeepAlive(t)
+
+	if !ok {
+		panic(fmt.Sprintf("validator: selected field is not a field of '%s'", b.typ))
+	}
+
+	return path
+}
+
+// findFieldPath returns the path of the field of the given type at the offset
+// within the struct type, searching the fields of nested structs.
+func findFieldPath(typ reflect.Type, offset uintptr, fieldType reflect.Type) (string, bool) {
+
+	for i := 0; i < typ.NumField(); i++ {
+
+		fld := typ.Field(i)
+
+		// zero sized fields share their offset with the following field
+		if offset < fld.Offset || (offset != fld.Offset && offset >= fld.Offset+fld.Type.Size()) {
+			continue
+		}
+
+		if offset == fld.Offset && fld.Type == fieldType {
+			return fld.Name, true
+		}
+
+		if fld.Type.Kind() == reflect.Struct {
+			if path, ok := findFieldPath(fld.Type, offset-fld.Offset, fieldType); ok {
+				return fld.Name + namespaceSeparator + path, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"reflect"
+	"strings"
 )
 
 // StructLevelFunc accepts all values needed for struct level validation
@@ -66,7 +67,37 @@ type StructLevel interface {
 	ReportValidationErrors(relativeNamespace, relativeActualNamespace string, errs ValidationErrors)
 }
 
+// StructLevelWarnings is implemented by the StructLevel passed to struct level
+// validations, it's kept apart from StructLevel so that adding it doesn't break
+// the existing implementations of StructLevel eg.
+//
+//	if w, ok := sl.(validator.StructLevelWarnings); ok {
+//	    w.ReportWarning(user.Name, "Name", "Name", "reserved", "")
+//	}
+type StructLevelWarnings interface {
+
+	// ReportWarning does the same as ReportError except that the FieldError is
+	// reported with SeverityWarning and so does not fail validation.
+	//
+	// NOTE: warnings are only returned when validating using StructResult
+	ReportWarning(field interface{}, fieldName, structFieldName string, tag, param string)
+}
+
+// StructLevelFailures is implemented by the StructLevel passed to struct level
+// validations, kept apart from StructLevel the same as StructLevelWarnings.
+type StructLevelFailures interface {
+
+	// FieldFailed returns whether any of the validations of the current struct's field, given by its struct field
+	// name eg. 'Email', or of the values nested within it eg. 'Address.City' or 'Emails[0]', have failed.
+	//
+	// NOTE: struct level validations run after the fields are validated, so this is always false when using
+	// WithStructLevelBeforeFields.
+	FieldFailed(structFieldName string) bool
+}
+
 var _ StructLevel = new(validate)
+var _ StructLevelWarnings = new(validate)
+var _ StructLevelFailures = new(validate)
 
 // Top returns the top level struct
 //
@@ -107,8 +138,18 @@ func (v *validate) ExtractType(field reflect.Value) (reflect.Value, reflect.Kind
 
 // ReportError reports an error just by passing the field and tag information
 func (v *validate) ReportError(field interface{}, fieldName, structFieldName, tag, param string) {
+	v.reportStructLevel(field, fieldName, structFieldName, tag, param, SeverityError)
+}
+
+// ReportWarning reports a warning just by passing the field and tag information
+func (v *validate) ReportWarning(field interface{}, fieldName, structFieldName, tag, param string) {
+	v.reportStructLevel(field, fieldName, structFieldName, tag, param, SeverityWarning)
+}
+
+func (v *validate) reportStructLevel(field interface{}, fieldName, structFieldName, tag, param string, severity Severity) {
 
 	fv, kind, _ := v.extractTypeInternal(reflect.ValueOf(field), false)
+	_, sensitive := v.v.sensitiveTags[tag]
 
 	if len(structFieldName) == 0 {
 		structFieldName = fieldName
@@ -116,15 +157,32 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 
 	v.str1 = string(append(v.ns, fieldName...))
 
-	if v.v.hasTagNameFunc || fieldName != structFieldName {
+	if v.v.hasTagNameFunc || v.hasRootName || fieldName != structFieldName {
 		v.str2 = string(append(v.actualNs, structFieldName...))
 	} else {
 		v.str2 = v.str1
 	}
 
+	// the field's names under the naming schemes when it's one of the struct's fields
+	var cf *cField
+
+	if len(v.v.nameSchemes) > 0 {
+
+		cf = &cField{altName: fieldName}
+
+		if cs, ok := v.v.structCache.Get(v.slCurrent.Type()); ok {
+			for i := 0; i < len(cs.fields); i++ {
+				if cs.fields[i].name == structFieldName {
+					cf.altNames = cs.fields[i].altNames
+					break
+				}
+			}
+		}
+	}
+
 	if kind == reflect.Invalid {
 
-		v.errs = append(v.errs,
+		v.report(
 			&fieldError{
 				v:              v.v,
 				tag:            tag,
@@ -135,12 +193,15 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 				structfieldLen: uint8(len(structFieldName)),
 				param:          param,
 				kind:           kind,
+				severity:       severity,
 			},
+			cf,
+			false,
 		)
 		return
 	}
 
-	v.errs = append(v.errs,
+	v.report(
 		&fieldError{
 			v:              v.v,
 			tag:            tag,
@@ -153,10 +214,35 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 			param:          param,
 			kind:           kind,
 			typ:            fv.Type(),
+			severity:       severity,
 		},
+		cf,
+		sensitive,
 	)
 }
 
+// FieldFailed returns whether any of the validations of the current struct's field, or the values nested within it,
+// have failed.
+func (v *validate) FieldFailed(structFieldName string) bool {
+
+	prefix := string(append(v.actualNs, structFieldName...))
+
+	for _, err := range v.errs[v.slErrs:] {
+
+		ns := err.StructNamespace()
+
+		if !strings.HasPrefix(ns, prefix) {
+			continue
+		}
+
+		if len(ns) == len(prefix) || ns[len(prefix)] == '.' || ns[len(prefix)] == '[' {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ReportValidationErrors reports ValidationErrors obtained from running validations within the Struct Level validation.
 //
 // NOTE: this function prepends the current namespace to the relative ones.
@@ -170,6 +256,6 @@ func (v *validate) ReportValidationErrors(relativeNamespace, relativeStructNames
 		err.ns = string(append(append(v.ns, relativeNamespace...), err.ns...))
 		err.structNs = string(append(append(v.actualNs, relativeStructNamespace...), err.structNs...))
 
-		v.errs = append(v.errs, err)
+		v.report(err, nil, false)
 	}
 }
@@ -854,6 +854,72 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 				return t
 			},
 		},
+		{
+			tag:         "before",
+			translation: "{0} must be before {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				t, err := ut.T(fe.Tag(), fe.Field(), timeBound(fe.Param()))
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+
+				return t
+			},
+		},
+		{
+			tag:         "after",
+			translation: "{0} must be after {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				t, err := ut.T(fe.Tag(), fe.Field(), timeBound(fe.Param()))
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+
+				return t
+			},
+		},
+		{
+			tag:         "within",
+			translation: "{0} must be within {1} of the current time",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				t, err := ut.T(fe.Tag(), fe.Field(), timeDuration(fe.Param()))
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+
+				return t
+			},
+		},
+		{
+			tag:         "not_older_than",
+			translation: "{0} must not be older than {1}",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				t, err := ut.T(fe.Tag(), fe.Field(), timeDuration(fe.Param()))
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+
+				return t
+			},
+		},
+		{
+			tag:         "exists",
+			translation: "{0} does not exist",
+			override:    false,
+		},
+		{
+			tag:         "unique_in",
+			translation: "{0} is already in use",
+			override:    false,
+		},
 		{
 			tag:         "eqfield",
 			translation: "{0} must be equal to {1}",
@@ -1277,6 +1343,39 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 			translation: "{0} must contain a valid CIDR notation for an IPv6 address",
 			override:    false,
 		},
+		{
+			tag:         "ip_in",
+			translation: "{0} must be an IP address within [{1}]",
+			override:    false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				s, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					log.Printf("warning: error translating FieldError: %#v", fe)
+					return fe.(error).Error()
+				}
+				return s
+			},
+		},
+		{
+			tag:         "public_ip",
+			translation: "{0} must be a public IP address",
+			override:    false,
+		},
+		{
+			tag:         "private_ip",
+			translation: "{0} must be a private IP address",
+			override:    false,
+		},
+		{
+			tag:         "loopback_ip",
+			translation: "{0} must be a loopback IP address",
+			override:    false,
+		},
+		{
+			tag:         "multicast_ip",
+			translation: "{0} must be a multicast IP address",
+			override:    false,
+		},
 		{
 			tag:         "tcp_addr",
 			translation: "{0} must be a valid TCP address",
@@ -1483,3 +1582,67 @@ func translateFunc(ut ut.Translator, fe validator.FieldError) string {
 
 	return t
 }
+
+// timeBound returns the time of the param of the 'before' and 'after' tags in words eg. '1 hour ago' for 'now-1h',
+// without the layout of string fields.
+func timeBound(param string) string {
+
+	param, _ = validator.TimeParam(param)
+
+	switch {
+	case param == "now":
+		return "the current time"
+	case strings.HasPrefix(param, "now+"):
+		return duration(param[4:]) + " from now"
+	case strings.HasPrefix(param, "now-"):
+		return duration(param[4:]) + " ago"
+	}
+
+	return param
+}
+
+// timeDuration returns the duration of the param of the 'within' and 'not_older_than' tags in words eg. '30 days' for
+// '30d', without the layout of string fields.
+func timeDuration(param string) string {
+	param, _ = validator.TimeParam(param)
+	return duration(param)
+}
+
+// duration returns the duration in words using its largest whole unit eg. '2 weeks' for '2w' or '90 minutes' for
+// '1h30m', as is when it can't be parsed.
+func duration(s string) string {
+
+	if n := len(s); n > 1 && (s[n-1] == 'd' || s[n-1] == 'w') {
+		if i, err := strconv.Atoi(s[:n-1]); err == nil {
+			if s[n-1] == 'w' {
+				return plural(i, "week")
+			}
+			return plural(i, "day")
+		}
+		return s
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return s
+	}
+
+	switch {
+	case d%time.Hour == 0:
+		return plural(int(d/time.Hour), "hour")
+	case d%time.Minute == 0:
+		return plural(int(d/time.Minute), "minute")
+	case d%time.Second == 0:
+		return plural(int(d/time.Second), "second")
+	}
+
+	return d.String()
+}
+
+// plural returns the count followed by the unit, pluralized unless the count is one.
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
@@ -15,7 +15,10 @@ func TestTranslations(t *testing.T) {
 	uni := ut.New(eng, eng)
 	trans, _ := uni.GetTranslator("en")
 
-	validate := validator.New()
+	lookup := validator.NewMemoryLookup()
+	lookup.Add("users", 1, map[string]interface{}{"email": "joey@example.com"})
+
+	validate := validator.New(validator.WithLookupProvider(lookup))
 
 	err := RegisterDefaultTranslations(validate, trans)
 	Equal(t, err, nil)
@@ -143,6 +146,17 @@ func TestTranslations(t *testing.T) {
 		CIDR               string            `validate:"cidr"`
 		CIDRv4             string            `validate:"cidrv4"`
 		CIDRv6             string            `validate:"cidrv6"`
+		IPIn               string            `validate:"ip_in=10.0.0.0/8"`
+		PublicIP           string            `validate:"public_ip"`
+		PrivateIP          string            `validate:"private_ip"`
+		LoopbackIP         string            `validate:"loopback_ip"`
+		MulticastIP        string            `validate:"multicast_ip"`
+		Before             string            `validate:"before=2020-01-01 2006-01-02"`
+		After              string            `validate:"after=now-1h"`
+		Within             string            `validate:"within=24h 2006-01-02"`
+		NotOlderThan       string            `validate:"not_older_than=30d"`
+		Exists             int               `validate:"exists=users.id"`
+		UniqueIn           string            `validate:"unique_in=users.email"`
 		TCPAddr            string            `validate:"tcp_addr"`
 		TCPAddrv4          string            `validate:"tcp4_addr"`
 		TCPAddrv6          string            `validate:"tcp6_addr"`
@@ -194,6 +208,7 @@ func TestTranslations(t *testing.T) {
 	test.Inner.ExcludedWith = "1234"
 	test.Inner.ExcludedWithAll = "1234"
 
+	test.UniqueIn = "joey@example.com"
 	test.ExcludedIf = "1234"
 	test.ExcludedUnless = "1234"
 	test.ExcludedWith = "1234"
@@ -320,6 +335,50 @@ func TestTranslations(t *testing.T) {
 			ns:       "Test.CIDRv6",
 			expected: "CIDRv6 must contain a valid CIDR notation for an IPv6 address",
 		},
+		{
+			ns:       "Test.IPIn",
+			expected: "IPIn must be an IP address within [10.0.0.0/8]",
+		},
+		{
+			ns:       "Test.PublicIP",
+			expected: "PublicIP must be a public IP address",
+		},
+		{
+			ns:       "Test.PrivateIP",
+			expected: "PrivateIP must be a private IP address",
+		},
+		{
+			ns:       "Test.LoopbackIP",
+			expected: "LoopbackIP must be a loopback IP address",
+		},
+		{
+			ns:       "Test.MulticastIP",
+			expected: "MulticastIP must be a multicast IP address",
+		},
+		{
+			ns:       "Test.Before",
+			expected: "Before must be before 2020-01-01",
+		},
+		{
+			ns:       "Test.After",
+			expected: "After must be after 1 hour ago",
+		},
+		{
+			ns:       "Test.Within",
+			expected: "Within must be within 24 hours of the current time",
+		},
+		{
+			ns:       "Test.NotOlderThan",
+			expected: "NotOlderThan must not be older than 30 days",
+		},
+		{
+			ns:       "Test.Exists",
+			expected: "Exists does not exist",
+		},
+		{
+			ns:       "Test.UniqueIn",
+			expected: "UniqueIn is already in use",
+		},
 		{
 			ns:       "Test.SSN",
 			expected: "SSN must be a valid SSN number",
@@ -809,3 +868,26 @@ func TestTranslations(t *testing.T) {
 		Equal(t, tt.expected, fe.Translate(trans))
 	}
 }
+
+func TestTimeParams(t *testing.T) {
+	tests := []struct {
+		param    string
+		bound    string
+		duration string
+	}{
+		{"now", "the current time", "now"},
+		{"now+1h", "1 hour from now", "now+1h"},
+		{"now-30d 2006-01-02", "30 days ago", "now-30d"},
+		{"now-1h30m", "90 minutes ago", "now-1h30m"},
+		{"2020-01-01 02/01/2006", "2020-01-01", "2020-01-01"},
+		{"2w", "2w", "2 weeks"},
+		{"1d 2006-01-02", "1d", "1 day"},
+		{"24h", "24h", "24 hours"},
+		{"1500ms", "1500ms", "1.5s"},
+	}
+
+	for _, tt := range tests {
+		Equal(t, timeBound(tt.param), tt.bound)
+		Equal(t, timeDuration(tt.param), tt.duration)
+	}
+}
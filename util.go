@@ -1,19 +1,34 @@
 package validator
 
 import (
+	"database/sql"
+	"encoding"
 	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unsafe"
 )
 
+// maxCustomTypeConversions limits the number of CustomTypeFunc's applied to a value, guarding against functions
+// converting values back and forth between types.
+const maxCustomTypeConversions = 8
+
 // extractTypeInternal gets the actual underlying type of field value.
 // It will dive into pointers, customTypes and return you the
 // underlying value and it's kind.
 func (v *validate) extractTypeInternal(current reflect.Value, nullable bool) (reflect.Value, reflect.Kind, bool) {
 
+	var conversions int
+
 BEGIN:
 	switch current.Kind() {
 	case reflect.Ptr:
@@ -43,10 +58,27 @@ BEGIN:
 
 	default:
 
-		if v.v.hasCustomFuncs {
+		if v.v.hasCustomFuncs && !v.modifying && conversions < maxCustomTypeConversions {
+
+			if fn, addr := v.v.customTypeFunc(current.Type()); fn != nil {
+
+				typ := current.Type()
+
+				if addr {
+					if current = addressable(current); !current.IsValid() {
+						return current, reflect.Invalid, nullable
+					}
+					current = current.Addr()
+				}
 
-			if fn, ok := v.v.customFuncs[current.Type()]; ok {
 				current = reflect.ValueOf(fn(current))
+
+				// guards against functions returning a value of the type they were passed, which would loop forever
+				if current.IsValid() && current.Type() == typ {
+					return current, current.Kind(), nullable
+				}
+
+				conversions++
 				goto BEGIN
 			}
 		}
@@ -55,6 +87,178 @@ BEGIN:
 	}
 }
 
+// isZero reports whether the value is the zero value of its type, using its IsZero method when it has one such as for
+// time.Time, whose zero value can be in any location.
+func isZero(field reflect.Value) bool {
+
+	if z, ok := asInterface(field, zeroerType).(zeroer); ok {
+		return z.IsZero()
+	}
+
+	return field.IsZero()
+}
+
+// lengthOf returns the length of the value using its Len method, for types such as ordered sets which aren't slices or
+// maps, see the 'len', 'min' and 'max' validations.
+func lengthOf(field reflect.Value) (int64, bool) {
+
+	if l, ok := asInterface(field, lennerType).(lenner); ok {
+		return int64(l.Len()), true
+	}
+
+	return 0, false
+}
+
+// compareFields compares the values using their Len method, or their Compare or Cmp method, see compareValues.
+func compareFields(field reflect.Value, other reflect.Value) (int, bool) {
+
+	if n, ok := lengthOf(field); ok {
+		if m, ok := lengthOf(other); ok {
+			return compareInts(n, m), true
+		}
+	}
+
+	return compareValues(field, other)
+}
+
+// compareParam compares the value to the param using the value's Len method, or its Compare or Cmp method with the
+// param parsed into the value's type, see parseParam.
+func compareParam(field reflect.Value, param string) (int, bool) {
+
+	if n, ok := lengthOf(field); ok {
+		return compareInts(n, asInt(param)), true
+	}
+
+	if _, ok := compareMethod(field); !ok {
+		return 0, false
+	}
+
+	return compareValues(field, parseParam(field.Type(), param))
+}
+
+// compareValues compares the values using the first value's Compare or Cmp method, such as those of big.Int and
+// decimal types, which take either a value of the type or a pointer to one and return -1, 0 or +1.
+func compareValues(field reflect.Value, other reflect.Value) (int, bool) {
+
+	m, ok := compareMethod(field)
+	if !ok || !other.CanInterface() {
+		return 0, false
+	}
+
+	argType := m.Type().In(0)
+
+	switch {
+	case other.Type() == argType:
+	case reflect.PtrTo(other.Type()) == argType:
+		other = addressable(other).Addr()
+	case other.Kind() == reflect.Ptr && other.Type().Elem() == argType && !other.IsNil():
+		other = other.Elem()
+	default:
+		return 0, false
+	}
+
+	return int(m.Call([]reflect.Value{other})[0].Int()), true
+}
+
+// compareMethod returns the value's Compare or Cmp method, including those with a pointer receiver. time.Time is
+// excluded as it has its own handling in each of the validations.
+func compareMethod(field reflect.Value) (reflect.Value, bool) {
+
+	if !field.CanInterface() || field.Kind() == reflect.Interface || field.Type().ConvertibleTo(timeType) {
+		return reflect.Value{}, false
+	}
+
+	ptrType := reflect.PtrTo(field.Type())
+
+	for _, name := range [...]string{"Compare", "Cmp"} {
+
+		m, ok := ptrType.MethodByName(name)
+		if !ok || m.Type.NumIn() != 2 || m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != reflect.Int {
+			continue
+		}
+
+		return addressable(field).Addr().MethodByName(name), true
+	}
+
+	return reflect.Value{}, false
+}
+
+// parseParam parses the param into a value of the type, using the type's ParseParam method, which returns the value,
+// or a pointer to it, and optionally an error, or its UnmarshalText method. It panics when the param can't be parsed.
+func parseParam(typ reflect.Type, param string) reflect.Value {
+
+	ptr := reflect.New(typ)
+
+	if m := ptr.MethodByName("ParseParam"); m.IsValid() {
+
+		mt := m.Type()
+		if mt.NumIn() == 1 && mt.In(0).Kind() == reflect.String && (mt.NumOut() == 1 || mt.NumOut() == 2 && mt.Out(1) == errorType) {
+
+			out := m.Call([]reflect.Value{reflect.ValueOf(param).Convert(mt.In(0))})
+			if len(out) == 2 && !out[1].IsNil() {
+				panic(out[1].Interface())
+			}
+
+			return out[0]
+		}
+	}
+
+	if u, ok := ptr.Interface().(encoding.TextUnmarshaler); ok {
+		panicIf(u.UnmarshalText([]byte(param)))
+		return ptr.Elem()
+	}
+
+	panic(fmt.Sprintf("Bad param '%s' for type '%s', it requires a ParseParam or UnmarshalText method", param, typ))
+}
+
+// compareInts returns -1, 0 or +1 depending on whether a is less than, equal to or greater than b
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// asInterface returns the value, or a pointer to it when only the pointer implements the interface, as an interface{}
+// which implements the given interface, or nil when neither do.
+func asInterface(field reflect.Value, iface reflect.Type) interface{} {
+
+	typ := field.Type()
+
+	if typ.Implements(iface) {
+		return getValue(field)
+	}
+
+	if typ.Kind() != reflect.Interface && reflect.PtrTo(typ).Implements(iface) {
+		if f := addressable(field); f.IsValid() {
+			return reflect.NewAt(typ, unsafe.Pointer(f.UnsafeAddr())).Interface()
+		}
+	}
+
+	return nil
+}
+
+// addressable returns the value if it's addressable, otherwise an addressable copy of it or the zero Value when it
+// can't be copied because it was obtained through an unexported field.
+func addressable(current reflect.Value) reflect.Value {
+
+	if current.CanAddr() {
+		return current
+	}
+
+	if !current.CanInterface() {
+		return reflect.Value{}
+	}
+
+	cp := reflect.New(current.Type()).Elem()
+	cp.Set(current)
+	return cp
+}
+
 // getStructFieldOKInternal traverses a struct to retrieve a specific field denoted by the provided namespace and
 // returns the field, field kind and whether is was successful in retrieving the field at all.
 //
@@ -289,6 +493,208 @@ func asBool(param string) bool {
 	return i
 }
 
+// asDuration returns the parameter as a time.Duration, which may also be a whole number of days or weeks eg. 30d or
+// 2w, or panics if it can't convert
+func asDuration(param string) time.Duration {
+
+	if n := len(param); n > 1 && (param[n-1] == 'd' || param[n-1] == 'w') {
+
+		days := asInt(param[:n-1])
+		if param[n-1] == 'w' {
+			days *= 7
+		}
+
+		return time.Duration(days) * 24 * time.Hour
+	}
+
+	d, err := time.ParseDuration(param)
+	panicIf(err)
+
+	return d
+}
+
+// TimeParam splits the param of the 'before', 'after', 'within' and 'not_older_than' tags into the time, or duration,
+// and the optional layout of string fields which follows the first space eg. 'now+1h 2006-01-02 15:04:05', such as
+// for use in translations.
+func TimeParam(param string) (string, string) {
+
+	if idx := strings.IndexByte(param, ' '); idx != -1 {
+		return param[:idx], strings.TrimSpace(param[idx+1:])
+	}
+
+	return param, ""
+}
+
+// asTime returns the parameter as a time.Time, which is either now, relative to now eg. now+1h or now-30d, a time in
+// the layout when one is given or a RFC 3339 date-time or date, or panics if it can't convert
+func asTime(param string, layout string, now time.Time) time.Time {
+
+	switch {
+	case param == "now":
+		return now
+
+	case strings.HasPrefix(param, "now+"):
+		return now.Add(asDuration(param[4:]))
+
+	case strings.HasPrefix(param, "now-"):
+		return now.Add(-asDuration(param[4:]))
+	}
+
+	if len(layout) > 0 {
+		if t, err := time.Parse(layout, param); err == nil {
+			return t
+		}
+	}
+
+	t, ok := parseTime(param)
+	if !ok {
+		panic(fmt.Sprintf("Bad time param '%s'", param))
+	}
+
+	return t
+}
+
+// parseTime parses a RFC 3339 date-time, or a date eg. 2006-01-02 as midnight UTC.
+func parseTime(s string) (time.Time, bool) {
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, true
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+
+	return t, err == nil
+}
+
+// fieldTime returns the time of a time.Time field, or of a string field containing a time in the layout when one is
+// given or otherwise a RFC 3339 date-time or date, false being returned for strings which can't be parsed.
+func fieldTime(field reflect.Value, layout string) (time.Time, bool) {
+
+	switch field.Kind() {
+	case reflect.String:
+		if len(layout) > 0 {
+			t, err := time.Parse(layout, field.String())
+			return t, err == nil
+		}
+
+		return parseTime(field.String())
+
+	case reflect.Struct:
+		if field.Type().ConvertibleTo(timeType) {
+			return field.Convert(timeType).Interface().(time.Time), true
+		}
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// currentTime returns the current time in UTC using the clock of the call, see WithClock and ContextWithClock.
+func currentTime(fl FieldLevel) time.Time {
+
+	if v, ok := fl.(*validate); ok && v.clock != nil {
+		return v.clock().UTC()
+	}
+
+	return time.Now().UTC()
+}
+
+// isValueStruct reports whether the struct type is validated as a value instead of as a nested struct, such as
+// time.Time and the types accepted by the network validations.
+func isValueStruct(typ reflect.Type) bool {
+
+	switch typ {
+	case urlType, netipAddrType, netipPrefixType, netipAddrPortType:
+		return true
+	}
+
+	return typ.ConvertibleTo(timeType)
+}
+
+// splitString splits the string for the 'split', 'splittrim' and 'splitws' tags, an empty string having no elements.
+func splitString(s string, ct *cTag) []string {
+
+	var parts []string
+
+	switch {
+	case ct.tag == splitWSTag:
+		parts = strings.Fields(s)
+	case len(s) > 0:
+		parts = strings.Split(s, ct.param)
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+
+	if ct.tag == splitTrimTag {
+		for i := 0; i < len(parts); i++ {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+	}
+
+	return parts
+}
+
+// netString returns the field's value as a string for the network validations, which accept the net/netip types,
+// net.IP and url.URL as well as strings, and whether it's one of them. Invalid netip values are an empty string.
+func netString(field reflect.Value) (string, bool) {
+
+	if field.Kind() == reflect.String {
+		return field.String(), true
+	}
+
+	switch v := getValue(field).(type) {
+	case netip.Addr:
+		if v.IsValid() {
+			return v.String(), true
+		}
+		return "", true
+	case netip.Prefix:
+		if v.IsValid() {
+			return v.String(), true
+		}
+		return "", true
+	case netip.AddrPort:
+		if v.IsValid() {
+			return v.String(), true
+		}
+		return "", true
+	case net.IP:
+		if len(v) > 0 {
+			return v.String(), true
+		}
+		return "", true
+	case url.URL:
+		return v.String(), true
+	}
+
+	return field.String(), false
+}
+
+// netipAddr returns the field's value as an IP address, parsing strings and converting net.IP and the address of a
+// netip.AddrPort, with IPv4-mapped IPv6 addresses unmapped, and whether it's a valid address.
+func netipAddr(field reflect.Value) (netip.Addr, bool) {
+
+	var addr netip.Addr
+
+	if field.Kind() == reflect.String {
+		addr, _ = netip.ParseAddr(field.String())
+	} else {
+		switch v := getValue(field).(type) {
+		case netip.Addr:
+			addr = v
+		case netip.AddrPort:
+			addr = v.Addr()
+		case net.IP:
+			addr, _ = netip.AddrFromSlice(v)
+		default:
+			panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+		}
+	}
+
+	return addr.Unmap(), addr.IsValid()
+}
+
 func panicIf(err error) {
 	if err != nil {
 		panic(err.Error())
@@ -310,3 +716,365 @@ func fieldMatchesRegexByStringerValOrString(regexFn func() *regexp.Regexp, fl Fi
 		}
 	}
 }
+
+// sortMapKeys sorts map keys of ordered kinds by their value and keys of all
+// other kinds by their fmt %v representation.
+func sortMapKeys(keys []reflect.Value) {
+
+	if len(keys) < 2 {
+		return
+	}
+
+	switch keys[0].Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Int() < keys[j].Int() })
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Uint() < keys[j].Uint() })
+
+	case reflect.Float32, reflect.Float64:
+		sort.Slice(keys, func(i, j int) bool {
+			a, b := keys[i].Float(), keys[j].Float()
+			return a < b || (math.IsNaN(a) && !math.IsNaN(b))
+		})
+
+	case reflect.String:
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	case reflect.Bool:
+		sort.Slice(keys, func(i, j int) bool { return !keys[i].Bool() && keys[j].Bool() })
+
+	default:
+		strs := make([]string, len(keys))
+		for i := 0; i < len(keys); i++ {
+			strs[i] = fmt.Sprintf("%v", keys[i].Interface())
+		}
+
+		sort.Stable(mapKeysByString{keys: keys, strs: strs})
+	}
+}
+
+type mapKeysByString struct {
+	keys []reflect.Value
+	strs []string
+}
+
+func (m mapKeysByString) Len() int { return len(m.keys) }
+
+func (m mapKeysByString) Less(i, j int) bool { return m.strs[i] < m.strs[j] }
+
+func (m mapKeysByString) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.strs[i], m.strs[j] = m.strs[j], m.strs[i]
+}
+
+// comparePath compares two namespaces, runs of digits such as slice indexes
+// are compared by their numeric value instead of character by character.
+func comparePath(a, b string) int {
+
+	var i, j int
+
+	for i < len(a) && j < len(b) {
+
+		if isDigit(a[i]) && isDigit(b[j]) {
+
+			si, sj := i, j
+
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+
+			da := strings.TrimLeft(a[si:i], "0")
+			db := strings.TrimLeft(b[sj:j], "0")
+
+			if len(da) != len(db) {
+				if len(da) < len(db) {
+					return -1
+				}
+				return 1
+			}
+
+			if c := strings.Compare(da, db); c != 0 {
+				return c
+			}
+
+			continue
+		}
+
+		if a[i] != b[j] {
+			if a[i] < b[j] {
+				return -1
+			}
+			return 1
+		}
+
+		i++
+		j++
+	}
+
+	switch {
+	case len(a)-i < len(b)-j:
+		return -1
+	case len(a)-i > len(b)-j:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// jsonFieldName returns the field's name in its encoding/json representation,
+// or an empty string when the field's Go name is used.
+func jsonFieldName(fld reflect.StructField) string {
+
+	if isSkippedJSONField(fld) {
+		return ""
+	}
+
+	tag := fld.Tag.Get("json")
+
+	name := tag
+	if idx := strings.IndexByte(tag, ','); idx != -1 {
+		name = tag[:idx]
+	}
+
+	if !isValidJSONName(name) {
+		return ""
+	}
+
+	return name
+}
+
+// isSkippedJSONField returns if encoding/json skips the field, using `json:"-"`.
+func isSkippedJSONField(fld reflect.StructField) bool {
+	return fld.Tag.Get("json") == "-"
+}
+
+// isInlineJSONField returns if the field is an embedded struct whose fields
+// encoding/json promotes into the parent struct.
+func isInlineJSONField(fld reflect.StructField) bool {
+
+	if !fld.Anonymous {
+		return false
+	}
+
+	if isSkippedJSONField(fld) || len(jsonFieldName(fld)) > 0 {
+		return false
+	}
+
+	typ := fld.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	// only the fields of embedded structs are promoted
+	return typ.Kind() == reflect.Struct
+}
+
+// hiddenJSONFields returns the index paths, joined using '.', of the fields of
+// the struct type which encoding/json drops when promoting the fields of inlined
+// embedded structs: those hidden by a field of the same name at a shallower
+// depth, or by a tagged field at the same depth, and those whose name conflicts
+// with no dominant field.
+func hiddenJSONFields(typ reflect.Type) map[string]struct{} {
+
+	type jsonField struct {
+		name   string
+		tagged bool
+		index  []int
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var fields []jsonField
+
+	current := []embedded{{typ: typ}}
+	visited := make(map[reflect.Type]struct{})
+
+	// breadth first so that the fields are in order of depth, the fields of a
+	// type already visited at a shallower depth would be hidden anyway
+	for len(current) > 0 {
+
+		var next []embedded
+
+		for _, e := range current {
+
+			if _, ok := visited[e.typ]; ok {
+				continue
+			}
+
+			for i := 0; i < e.typ.NumField(); i++ {
+
+				fld := e.typ.Field(i)
+
+				ft := fld.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+
+				if len(fld.PkgPath) > 0 && (!fld.Anonymous || ft.Kind() != reflect.Struct) {
+					continue
+				}
+
+				if isSkippedJSONField(fld) {
+					continue
+				}
+
+				index := append(append(make([]int, 0, len(e.index)+1), e.index...), i)
+				name := jsonFieldName(fld)
+
+				if len(name) == 0 && fld.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, embedded{typ: ft, index: index})
+					continue
+				}
+
+				fields = append(fields, jsonField{name: name, tagged: len(name) > 0, index: index})
+				if len(name) == 0 {
+					fields[len(fields)-1].name = fld.Name
+				}
+			}
+		}
+
+		for _, e := range current {
+			visited[e.typ] = struct{}{}
+		}
+
+		current = next
+	}
+
+	byName := make(map[string][]jsonField, len(fields))
+	for _, f := range fields {
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	var hidden map[string]struct{}
+
+	for _, named := range byName {
+
+		if len(named) == 1 {
+			continue
+		}
+
+		// the dominant field is the only one at the shallowest depth, or the
+		// only tagged one at that depth, the rest being hidden
+		dominant := -1
+		depth := len(named[0].index)
+		conflict := false
+
+		for i, f := range named {
+			switch {
+			case len(f.index) > depth:
+				continue
+			case len(f.index) < depth:
+				depth, dominant, conflict = len(f.index), i, false
+			case dominant == -1:
+				dominant = i
+			case f.tagged && !named[dominant].tagged:
+				dominant, conflict = i, false
+			case f.tagged == named[dominant].tagged:
+				conflict = true
+			}
+		}
+
+		for i, f := range named {
+			if i == dominant && !conflict {
+				continue
+			}
+
+			if hidden == nil {
+				hidden = make(map[string]struct{})
+			}
+			hidden[jsonIndexPath(f.index)] = struct{}{}
+		}
+	}
+
+	return hidden
+}
+
+// jsonIndexPath joins the index path of a field using '.', see hiddenJSONFields.
+func jsonIndexPath(index []int) string {
+
+	b := make([]byte, 0, len(index)*2)
+
+	for i, idx := range index {
+		if i > 0 {
+			b = append(b, '.')
+		}
+		b = strconv.AppendInt(b, int64(idx), 10)
+	}
+
+	return string(b)
+}
+
+// isValidJSONName reports whether the name is allowed as a json key by
+// encoding/json, an invalid name is ignored in favour of the Go name.
+func isValidJSONName(name string) bool {
+
+	if name == "" {
+		return false
+	}
+
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// Backslash and quote chars are reserved, but
+			// otherwise any punctuation chars are allowed
+			// in a tag name.
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+
+	return true
+}
+
+// sqlNullValue returns the value held by the database/sql Null types, or nil when not valid, see WithSQLNullTypes.
+func sqlNullValue(field reflect.Value) interface{} {
+
+	switch n := getValue(field).(type) {
+	case sql.NullString:
+		if n.Valid {
+			return n.String
+		}
+	case sql.NullInt64:
+		if n.Valid {
+			return n.Int64
+		}
+	case sql.NullInt32:
+		if n.Valid {
+			return n.Int32
+		}
+	case sql.NullInt16:
+		if n.Valid {
+			return n.Int16
+		}
+	case sql.NullByte:
+		if n.Valid {
+			return n.Byte
+		}
+	case sql.NullFloat64:
+		if n.Valid {
+			return n.Float64
+		}
+	case sql.NullBool:
+		if n.Valid {
+			return n.Bool
+		}
+	case sql.NullTime:
+		if n.Valid {
+			return n.Time
+		}
+	}
+
+	return nil
+}
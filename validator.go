@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"time"
 	"unsafe"
 )
 
@@ -15,6 +17,7 @@ type validate struct {
 	ns             []byte
 	actualNs       []byte
 	errs           ValidationErrors
+	warns          ValidationErrors
 	includeExclude map[string]struct{} // reset only if StructPartial or StructExcept are called, no need otherwise
 	ffn            FilterFunc
 	slflParent     reflect.Value // StructLevel & FieldLevel
@@ -25,26 +28,43 @@ type validate struct {
 	misc           []byte        // misc reusable
 	str1           string        // misc reusable
 	str2           string        // misc reusable
+	fnErr          error         // error returned by the last failing FuncErr validation
 	fldIsPointer   bool          // StructLevel & FieldLevel
+	rootName       string        // see ContextWithRootName
+	hasRootName    bool          // see ContextWithRootName
+	scheme         int           // index of the naming scheme used for ns, see ContextWithNameScheme
+	path           []*cField     // nested struct fields of ns, only tracked when naming schemes are registered
+	slErrs         int           // StructLevel, number of errors before validating the current struct's fields
 	isPartial      bool
 	hasExcludes    bool
+	clock          func() time.Time    // see WithClock and ContextWithClock
+	async          []*asyncJob         // scheduled asynchronous validations, see RegisterAsyncValidation
+	missing        map[string]struct{} // struct namespaces of the fields whose keys are missing, see ValidateMapAs
+	abort          error               // failure returned instead of errs, see runAsync and modifyMap
+	modifying      bool                // running the modifiers of the fields instead of their validations, see Normalize
 }
 
-// parent and current will be the same the first run of validateStruct
-func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, current reflect.Value, typ reflect.Type, ns []byte, structNs []byte, ct *cTag) {
+// parent and current will be the same the first run of validateStruct, cs is the cached struct when already known
+// eg. one built for the rule paths of a field, see cField.nested
+func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, current reflect.Value, typ reflect.Type, ns []byte, structNs []byte, ct *cTag, cs *cStruct) {
 
-	cs, ok := v.v.structCache.Get(typ)
-	if !ok {
-		cs = v.v.extractStructCache(current, typ.Name())
+	var ok bool
+
+	if cs == nil {
+		cs, ok = v.v.structCache.Get(typ)
+		if !ok {
+			cs = v.v.extractStructCache(current, typ.Name())
+		}
 	}
 
-	if len(ns) == 0 && len(cs.name) != 0 {
+	if len(ns) == 0 && len(structNs) == 0 {
+		ns, structNs = v.appendRoot(ns, structNs, cs)
+	}
 
-		ns = append(ns, cs.name...)
-		ns = append(ns, '.')
+	errs := len(v.errs)
 
-		structNs = append(structNs, cs.name...)
-		structNs = append(structNs, '.')
+	if cs.fn != nil && v.v.structLevelBeforeFields && !v.modifying {
+		v.structLevel(ctx, parent, current, ns, structNs, cs, errs)
 	}
 
 	// ct is nil on top level struct, and structs as fields that have no tag info
@@ -52,11 +72,24 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 	if ct == nil || ct.typeof != typeStructOnly {
 
 		var f *cField
+		var fct *cTag
 
 		for i := 0; i < len(cs.fields); i++ {
 
 			f = cs.fields[i]
 
+			if fct = f.cTags; v.modifying {
+				if v.abort != nil {
+					return
+				}
+				fct = f.modTags
+			}
+
+			// fields are only kept for either their validations or modifiers
+			if fct == nil {
+				continue
+			}
+
 			if v.isPartial {
 
 				if v.ffn != nil {
@@ -75,29 +108,258 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 				}
 			}
 
-			v.traverseField(ctx, current, current.Field(f.idx), ns, structNs, f, f.cTags)
+			if v.missing != nil {
+				// the field's key is missing from the map data, see ValidateMapAs
+				if _, ok = v.missing[string(append(structNs, f.name...))]; ok {
+					v.validateMissing(ctx, current, ns, structNs, f)
+					continue
+				}
+			}
+
+			v.traverseField(ctx, current, current.Field(f.idx), ns, structNs, f, fct)
 		}
 	}
 
 	// check if any struct level validations, after all field validations already checked.
 	// first iteration will have no info about nostructlevel tag, and is checked prior to
 	// calling the next iteration of validateStruct called from traverseField.
-	if cs.fn != nil {
+	if cs.fn != nil && !v.v.structLevelBeforeFields && !v.modifying {
+
+		if v.v.structLevelSkipOnFieldErrors && len(v.errs) > errs {
+			return
+		}
+
+		v.structLevel(ctx, parent, current, ns, structNs, cs, errs)
+	}
+}
+
+// structLevel runs the struct level validation of the struct, errs being the number of errors before validating its
+// fields, see FieldFailed.
+func (v *validate) structLevel(ctx context.Context, parent reflect.Value, current reflect.Value, ns []byte, structNs []byte, cs *cStruct, errs int) {
+
+	v.slflParent = parent
+	v.slCurrent = current
+	v.ns = ns
+	v.actualNs = structNs
+	v.slErrs = errs
+
+	cs.fn(ctx, v)
+}
+
+// validateMissing validates a field whose key is missing from the map data, see ValidateMapAs. Only the 'required'
+// and conditional 'required_*' validations apply to the missing value, the same as for a field omitted using
+// 'omitempty', the others being skipped including those of its elements.
+func (v *validate) validateMissing(ctx context.Context, parent reflect.Value, ns []byte, structNs []byte, cf *cField) {
+
+	for ct := cf.cTags; ct != nil; ct = ct.next {
+
+		switch ct.typeof {
+		case typeDefault:
+		case typeOmitEmpty, typeOmitNil, typeOmitZero, typeDive, typeSplit, typeStructOnly, typeNoStructLevel:
+			return
+		default:
+			continue
+		}
+
+		switch ct.tag {
+		case requiredTag, requiredIfTag, requiredUnlessTag, requiredWithTag, requiredWithAllTag, requiredWithoutTag, requiredWithoutAllTag:
+		default:
+			continue
+		}
 
 		v.slflParent = parent
-		v.slCurrent = current
-		v.ns = ns
-		v.actualNs = structNs
+		v.flField = reflect.Value{}
+		v.fldIsPointer = false
+		v.cf = cf
+		v.ct = ct
+
+		if ct.fn(ctx, v) {
+			continue
+		}
+
+		v.str1 = string(append(ns, cf.altName...))
+		if v.v.hasTagNameFunc || v.hasRootName {
+			v.str2 = string(append(structNs, cf.name...))
+		} else {
+			v.str2 = v.str1
+		}
+
+		v.reportInvalid(cf, ct)
+
+		if ct.severity != SeverityWarning {
+			return
+		}
+	}
+}
+
+// reportInvalid reports the failure of the validation of a field without a value, whose namespaces are v.str1 and
+// v.str2.
+func (v *validate) reportInvalid(cf *cField, ct *cTag) {
+	v.report(
+		&fieldError{
+			v:              v.v,
+			tag:            ct.aliasTag,
+			actualTag:      ct.tag,
+			ns:             v.str1,
+			structNs:       v.str2,
+			fieldLen:       uint8(len(cf.altName)),
+			structfieldLen: uint8(len(cf.name)),
+			param:          ct.param,
+			kind:           reflect.Invalid,
+			severity:       ct.severity,
+		},
+		cf,
+		false,
+	)
+}
+
+// appendRoot appends the root of the namespaces for the top level struct.
+func (v *validate) appendRoot(ns []byte, structNs []byte, cs *cStruct) ([]byte, []byte) {
+
+	name := cs.name
+	if v.hasRootName {
+		name = v.rootName
+	}
+
+	if len(name) != 0 {
+		ns = append(ns, name...)
+		ns = append(ns, '.')
+	}
+
+	if len(cs.name) != 0 {
+		structNs = append(structNs, cs.name...)
+		structNs = append(structNs, '.')
+	}
+
+	return ns, structNs
+}
+
+// validateMap validates map data using a map of rules, see ValidateMapErr.
+func (v *validate) validateMap(ctx context.Context, parent reflect.Value, data map[string]interface{}, rules map[string]interface{}, ns []byte, structNs []byte) {
+
+	keys := make([]string, 0, len(rules))
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+
+		switch rule := rules[key].(type) {
+		case map[string]interface{}:
 
-		cs.fn(ctx, v)
+			switch d := data[key].(type) {
+			case map[string]interface{}:
+				v.validateMap(ctx, reflect.ValueOf(d), d, rule, append(append(ns, key...), '.'), append(append(structNs, key...), '.'))
+
+			case []map[string]interface{}:
+				for i := 0; i < len(d); i++ {
+					v.validateMap(ctx, reflect.ValueOf(d[i]), d[i], rule, appendMapIndex(ns, key, i, '.'), appendMapIndex(structNs, key, i, '.'))
+				}
+
+			case []interface{}:
+				for i := 0; i < len(d); i++ {
+					if obj, ok := d[i].(map[string]interface{}); ok {
+						v.validateMap(ctx, reflect.ValueOf(obj), obj, rule, appendMapIndex(ns, key, i, '.'), appendMapIndex(structNs, key, i, '.'))
+					} else {
+						v.reportMap(ns, string(appendMapIndex(nil, key, i, 0)), objectTag, d[i])
+					}
+				}
+
+			case nil:
+				if v.v.strictMaps {
+					v.reportMap(ns, key, requiredTag, nil)
+				}
+
+			default:
+				v.reportMap(ns, key, objectTag, d)
+			}
+
+		case string:
+
+			if len(rule) == 0 || rule == skipValidationTag {
+				continue
+			}
+
+			val := reflect.ValueOf(data[key])
+			cf := &cField{name: key, altName: key, namesEqual: true}
+
+			v.traverseField(ctx, parent, val, ns, structNs, cf, v.v.fetchCacheTag(rule))
+
+		default:
+			panic(fmt.Sprintf(invalidMapRule, append(ns, key...), rule))
+		}
+	}
+
+	if v.v.strictMaps {
+
+		keys = keys[0:0]
+		for k := range data {
+			if _, ok := rules[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			v.reportMap(ns, key, unknownKeyTag, data[key])
+		}
 	}
 }
 
+// reportMap reports a failure of the map data itself, rather than of a validation, see validateMap.
+func (v *validate) reportMap(ns []byte, key, tag string, value interface{}) {
+
+	val := reflect.ValueOf(value)
+
+	fe := &fieldError{
+		v:              v.v,
+		tag:            tag,
+		actualTag:      tag,
+		ns:             string(append(ns, key...)),
+		fieldLen:       uint8(len(key)),
+		structfieldLen: uint8(len(key)),
+		value:          value,
+		kind:           val.Kind(),
+	}
+	fe.structNs = fe.ns
+
+	if val.IsValid() {
+		fe.typ = val.Type()
+	}
+
+	v.report(fe, nil, false)
+}
+
+// appendMapIndex appends the key with the index eg. 'items[2]' to ns, followed by sep unless zero.
+func appendMapIndex(ns []byte, key string, i int, sep byte) []byte {
+
+	ns = append(ns, key...)
+	ns = append(ns, '[')
+	ns = strconv.AppendInt(ns, int64(i), 10)
+	ns = append(ns, ']')
+
+	if sep != 0 {
+		ns = append(ns, sep)
+	}
+
+	return ns
+}
+
 // traverseField validates any field, be it a struct or single field, ensures it's validity and passes it along to be validated via it's tag options
 func (v *validate) traverseField(ctx context.Context, parent reflect.Value, current reflect.Value, ns []byte, structNs []byte, cf *cField, ct *cTag) {
 	var typ reflect.Type
 	var kind reflect.Kind
 
+	if v.modifying {
+		if current.Kind() == reflect.Interface {
+			v.modifyInterface(ctx, parent, current, ns, structNs, cf, ct)
+			return
+		}
+
+		allocDefaults(current, ct)
+	}
+
 	current, kind, v.fldIsPointer = v.extractTypeInternal(current, false)
 
 	var isNestedStruct bool
@@ -105,50 +367,40 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 	switch kind {
 	case reflect.Ptr, reflect.Interface, reflect.Invalid:
 
-		if ct == nil {
+		// there's nothing to modify
+		if v.modifying {
 			return
 		}
 
-		if ct.typeof == typeOmitEmpty || ct.typeof == typeIsDefault {
-			return
-		}
+		// warnings never stop the remaining validations from running, so the tags following them are checked as well
+		for {
+			if ct == nil {
+				return
+			}
 
-		if ct.typeof == typeOmitNil && (kind != reflect.Invalid && current.IsNil()) {
-			return
-		}
+			if ct.typeof == typeOmitEmpty || ct.typeof == typeOmitZero || ct.typeof == typeIsDefault {
+				return
+			}
 
-		if ct.hasTag {
-			if kind == reflect.Invalid {
-				v.str1 = string(append(ns, cf.altName...))
-				if v.v.hasTagNameFunc {
-					v.str2 = string(append(structNs, cf.name...))
-				} else {
-					v.str2 = v.str1
-				}
-				v.errs = append(v.errs,
-					&fieldError{
-						v:              v.v,
-						tag:            ct.aliasTag,
-						actualTag:      ct.tag,
-						ns:             v.str1,
-						structNs:       v.str2,
-						fieldLen:       uint8(len(cf.altName)),
-						structfieldLen: uint8(len(cf.name)),
-						param:          ct.param,
-						kind:           kind,
-					},
-				)
+			if ct.typeof == typeOmitNil && (kind != reflect.Invalid && current.IsNil()) {
 				return
 			}
 
+			if !ct.hasTag || (kind != reflect.Invalid && ct.runValidationWhenNil) {
+				break
+			}
+
 			v.str1 = string(append(ns, cf.altName...))
-			if v.v.hasTagNameFunc {
+			if v.v.hasTagNameFunc || v.hasRootName {
 				v.str2 = string(append(structNs, cf.name...))
 			} else {
 				v.str2 = v.str1
 			}
-			if !ct.runValidationWhenNil {
-				v.errs = append(v.errs,
+
+			if kind == reflect.Invalid {
+				v.reportInvalid(cf, ct)
+			} else {
+				v.report(
 					&fieldError{
 						v:              v.v,
 						tag:            ct.aliasTag,
@@ -161,10 +413,18 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 						param:          ct.param,
 						kind:           kind,
 						typ:            current.Type(),
+						severity:       ct.severity,
 					},
+					cf,
+					ct.sensitive,
 				)
+			}
+
+			if ct.severity != SeverityWarning {
 				return
 			}
+
+			ct = ct.next
 		}
 
 		if kind == reflect.Invalid {
@@ -172,7 +432,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 		}
 
 	case reflect.Struct:
-		isNestedStruct = !current.Type().ConvertibleTo(timeType)
+		isNestedStruct = !isValueStruct(current.Type())
 		// For backward compatibility before struct level validation tags were supported
 		// as there were a number of projects relying on `required` not failing on non-pointer
 		// structs. Since it's basically nonsensical to use `required` with a non-pointer struct
@@ -185,9 +445,16 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 
 	typ = current.Type()
 
+	allErrs := v.v.allFieldErrors
+	var failed bool
+
 OUTER:
 	for {
 		if ct == nil || !ct.hasTag || (isNestedStruct && len(cf.name) == 0) {
+			if failed {
+				return
+			}
+
 			// isNestedStruct check here
 			if isNestedStruct {
 				// if len == 0 then validating using 'Var' or 'VarWithValue'
@@ -195,11 +462,21 @@ OUTER:
 				// VarWithField - this allows for validating against each field within the struct against a specific value
 				//                pretty handy in certain situations
 				if len(cf.name) > 0 {
-					ns = append(append(ns, cf.altName...), '.')
+					// inlined embedded structs are flattened into the parent's namespace
+					if !cf.inline {
+						ns = append(append(ns, cf.altName...), '.')
+					}
 					structNs = append(append(structNs, cf.name...), '.')
+
+					if len(v.v.nameSchemes) > 0 && !cf.inline {
+						v.path = append(v.path, cf)
+						v.validateStruct(ctx, parent, current, typ, ns, structNs, ct, cf.nested)
+						v.path = v.path[:len(v.path)-1]
+						return
+					}
 				}
 
-				v.validateStruct(ctx, parent, current, typ, ns, structNs, ct)
+				v.validateStruct(ctx, parent, current, typ, ns, structNs, ct, cf.nested)
 			}
 			return
 		}
@@ -215,11 +492,21 @@ OUTER:
 				// VarWithField - this allows for validating against each field within the struct against a specific value
 				//                pretty handy in certain situations
 				if len(cf.name) > 0 {
-					ns = append(append(ns, cf.altName...), '.')
+					// inlined embedded structs are flattened into the parent's namespace
+					if !cf.inline {
+						ns = append(append(ns, cf.altName...), '.')
+					}
 					structNs = append(append(structNs, cf.name...), '.')
+
+					if len(v.v.nameSchemes) > 0 && !cf.inline {
+						v.path = append(v.path, cf)
+						v.validateStruct(ctx, parent, current, typ, ns, structNs, ct, cf.nested)
+						v.path = v.path[:len(v.path)-1]
+						return
+					}
 				}
 
-				v.validateStruct(ctx, parent, current, typ, ns, structNs, ct)
+				v.validateStruct(ctx, parent, current, typ, ns, structNs, ct, cf.nested)
 			}
 			return
 
@@ -258,10 +545,36 @@ OUTER:
 			ct = ct.next
 			continue
 
+		case typeOmitZero:
+			if isZero(current) {
+				return
+			}
+
+			ct = ct.next
+			continue
+
+		case typeSplit:
+			// struct fields are checked when cached, see checkValueTypes, so this is only reached by values whose
+			// type is only known at runtime
+			if kind != reflect.String {
+				panic(fmt.Sprintf("'%s' tag requires a string, got %s", ct.tag, typ))
+			}
+
+			// the rest of the tags are applied to the elements as a []string, diving into them using 'dive'
+			current = reflect.ValueOf(splitString(current.String(), ct))
+			kind = reflect.Slice
+			typ = current.Type()
+
+			ct = ct.next
+			continue
+
 		case typeEndKeys:
 			return
 
 		case typeDive:
+			if failed {
+				return
+			}
 
 			ct = ct.next
 
@@ -295,15 +608,31 @@ OUTER:
 
 						reusableCF.altName = string(v.misc)
 					}
+
+					if len(cf.altNames) > 0 {
+						reusableCF.altNames = diveAltNames(cf.altNames, reusableCF.name[len(cf.name):])
+					}
+					reusableCF.nested = cf.nested
+
 					v.traverseField(ctx, parent, current.Index(i), ns, structNs, reusableCF, ct)
 				}
 
 			case reflect.Map:
 
+				if v.modifying {
+					v.modifyMap(ctx, parent, current, ns, structNs, cf, ct)
+					return
+				}
+
 				var pv string
 				reusableCF := &cField{}
 
-				for _, key := range current.MapKeys() {
+				keys := current.MapKeys()
+				if v.v.sortedMapKeys {
+					sortMapKeys(keys)
+				}
+
+				for _, key := range keys {
 
 					pv = fmt.Sprintf("%v", key.Interface())
 
@@ -325,13 +654,21 @@ OUTER:
 						reusableCF.altName = string(v.misc)
 					}
 
+					if len(cf.altNames) > 0 {
+						reusableCF.altNames = diveAltNames(cf.altNames, reusableCF.name[len(cf.name):])
+					}
+
 					if ct != nil && ct.typeof == typeKeys && ct.keys != nil {
+						// rule paths only apply to the map's values
+						reusableCF.nested = nil
 						v.traverseField(ctx, parent, key, ns, structNs, reusableCF, ct.keys)
 						// can be nil when just keys being validated
 						if ct.next != nil {
+							reusableCF.nested = cf.nested
 							v.traverseField(ctx, parent, current.MapIndex(key), ns, structNs, reusableCF, ct.next)
 						}
 					} else {
+						reusableCF.nested = cf.nested
 						v.traverseField(ctx, parent, current.MapIndex(key), ns, structNs, reusableCF, ct)
 					}
 				}
@@ -347,6 +684,7 @@ OUTER:
 		case typeOr:
 
 			v.misc = v.misc[0:0]
+			var sensitive bool
 
 			for {
 
@@ -356,6 +694,9 @@ OUTER:
 				v.cf = cf
 				v.ct = ct
 
+				// only the error of the last alternative is attached to the failure
+				v.fnErr = nil
+
 				if ct.fn(ctx, v) {
 					if ct.isBlockEnd {
 						ct = ct.next
@@ -382,6 +723,8 @@ OUTER:
 					}
 				}
 
+				sensitive = sensitive || ct.sensitive
+
 				v.misc = append(v.misc, '|')
 				v.misc = append(v.misc, ct.tag...)
 
@@ -394,7 +737,7 @@ OUTER:
 					// if we get here, no valid 'or' value and no more tags
 					v.str1 = string(append(ns, cf.altName...))
 
-					if v.v.hasTagNameFunc {
+					if v.v.hasTagNameFunc || v.hasRootName {
 						v.str2 = string(append(structNs, cf.name...))
 					} else {
 						v.str2 = v.str1
@@ -402,7 +745,7 @@ OUTER:
 
 					if ct.hasAlias {
 
-						v.errs = append(v.errs,
+						v.report(
 							&fieldError{
 								v:              v.v,
 								tag:            ct.aliasTag,
@@ -415,14 +758,18 @@ OUTER:
 								param:          ct.param,
 								kind:           kind,
 								typ:            typ,
+								err:            v.fnErr,
+								severity:       ct.severity,
 							},
+							cf,
+							sensitive,
 						)
 
 					} else {
 
 						tVal := string(v.misc)[1:]
 
-						v.errs = append(v.errs,
+						v.report(
 							&fieldError{
 								v:              v.v,
 								tag:            tVal,
@@ -435,10 +782,27 @@ OUTER:
 								param:          ct.param,
 								kind:           kind,
 								typ:            typ,
+								err:            v.fnErr,
+								severity:       ct.severity,
 							},
+							cf,
+							sensitive,
 						)
 					}
 
+					v.fnErr = nil
+
+					// warnings never stop the remaining validations from running
+					if ct.severity == SeverityWarning {
+						ct = ct.next
+						continue OUTER
+					}
+
+					if allErrs || ct.allErrors {
+						failed = true
+						ct = ct.next
+						continue OUTER
+					}
 					return
 				}
 
@@ -447,6 +811,51 @@ OUTER:
 
 		default:
 
+			if v.modifying {
+				if current.CanSet() {
+					v.slflParent = parent
+					v.flField = current
+					v.cf = cf
+					v.ct = ct
+
+					ct.mod(ctx, v)
+				}
+
+				ct = ct.next
+				continue
+			}
+
+			if ct.async != nil {
+
+				v.str1 = string(append(ns, cf.altName...))
+
+				if v.v.hasTagNameFunc || v.hasRootName {
+					v.str2 = string(append(structNs, cf.name...))
+				} else {
+					v.str2 = v.str1
+				}
+
+				v.scheduleAsync(parent, current, cf, ct,
+					&fieldError{
+						v:              v.v,
+						tag:            ct.aliasTag,
+						actualTag:      ct.tag,
+						ns:             v.str1,
+						structNs:       v.str2,
+						fieldLen:       uint8(len(cf.altName)),
+						structfieldLen: uint8(len(cf.name)),
+						value:          getValue(current),
+						param:          ct.param,
+						kind:           kind,
+						typ:            typ,
+						severity:       ct.severity,
+					},
+				)
+
+				ct = ct.next
+				continue
+			}
+
 			// set Field Level fields
 			v.slflParent = parent
 			v.flField = current
@@ -456,13 +865,13 @@ OUTER:
 			if !ct.fn(ctx, v) {
 				v.str1 = string(append(ns, cf.altName...))
 
-				if v.v.hasTagNameFunc {
+				if v.v.hasTagNameFunc || v.hasRootName {
 					v.str2 = string(append(structNs, cf.name...))
 				} else {
 					v.str2 = v.str1
 				}
 
-				v.errs = append(v.errs,
+				v.report(
 					&fieldError{
 						v:              v.v,
 						tag:            ct.aliasTag,
@@ -475,10 +884,22 @@ OUTER:
 						param:          ct.param,
 						kind:           kind,
 						typ:            typ,
+						err:            v.fnErr,
+						severity:       ct.severity,
 					},
+					cf,
+					ct.sensitive,
 				)
 
-				return
+				v.fnErr = nil
+
+				// warnings never stop the remaining validations from running
+				if ct.severity != SeverityWarning {
+					if _, isRequired := requiredTags[ct.tag]; !(allErrs || ct.allErrors) || isRequired {
+						return
+					}
+					failed = true
+				}
 			}
 			ct = ct.next
 		}
@@ -486,6 +907,82 @@ OUTER:
 
 }
 
+// setCallOptions sets the options of the current call which are passed using ctx, see ContextWithRootName and
+// ContextWithNameScheme.
+func (v *validate) setCallOptions(ctx context.Context) {
+	v.rootName, v.hasRootName = ctx.Value(rootNameCtxKey{}).(string)
+	v.scheme = -1
+	v.path = v.path[:0]
+
+	if scheme, ok := ctx.Value(nameSchemeCtxKey{}).(string); ok {
+		v.scheme = v.v.nameSchemeIndex(scheme)
+	}
+
+	v.clock = v.v.clock
+	if clock, ok := ctx.Value(clockCtxKey{}).(func() time.Time); ok && clock != nil {
+		v.clock = clock
+	}
+}
+
+// report adds the fieldError to the errors, or warnings, of the current call. cf is the field which failed, when known,
+// used to determine the field's names under the naming schemes.
+func (v *validate) report(fe *fieldError, cf *cField, sensitive bool) {
+
+	v.setPath(fe, cf)
+	v.redact(fe, sensitive)
+
+	if fe.severity == SeverityWarning {
+		v.warns = append(v.warns, fe)
+		return
+	}
+	v.errs = append(v.errs, fe)
+}
+
+// setPath sets the names of the fieldError's path under the naming schemes, using the namespace of the naming scheme
+// of the current call when passed one.
+func (v *validate) setPath(fe *fieldError, cf *cField) {
+
+	if cf != nil && len(v.v.nameSchemes) > 0 {
+
+		fe.path = make([]pathField, len(v.path)+1)
+		for i := 0; i < len(v.path); i++ {
+			fe.path[i] = pathField{altName: v.path[i].altName, altNames: v.path[i].altNames}
+		}
+		fe.path[len(v.path)] = pathField{altName: cf.altName, altNames: cf.altNames}
+
+		if v.scheme >= 0 {
+			scheme := v.v.nameSchemes[v.scheme].name
+			field := fe.FieldAs(scheme)
+			fe.ns = fe.NamespaceAs(scheme)
+			fe.fieldLen = uint8(len(field))
+			fe.nsScheme = scheme
+		}
+	}
+}
+
+// redact redacts the fieldError's value when sensitive so that it is never retained, see WithValueRedactor.
+func (v *validate) redact(fe *fieldError, sensitive bool) {
+
+	if sensitive {
+		if v.v.valueRedactor != nil {
+			fe.value = v.v.valueRedactor(fe)
+		} else {
+			fe.value = nil
+		}
+	}
+}
+
+// diveAltNames returns the names of a dived into element under each of the naming schemes.
+func diveAltNames(altNames []string, suffix string) []string {
+
+	names := make([]string, len(altNames))
+	for i := 0; i < len(altNames); i++ {
+		names[i] = altNames[i] + suffix
+	}
+
+	return names
+}
+
 func getValue(val reflect.Value) interface{} {
 	if val.CanInterface() {
 		return val.Interface()
@@ -508,3 +1005,32 @@ func getValue(val reflect.Value) interface{} {
 		return val.String()
 	}
 }
+
+// valueAs returns the value as T, reading it in place when it's addressable and of type T to avoid boxing it. When
+// T is a pointer type the value has already been dereferenced, so a pointer to it is returned instead. The values of
+// unexported fields aren't returned, the same as they can't be using FieldLevel.Field().Interface().
+func valueAs[T any](val reflect.Value, typ reflect.Type) (t T, ok bool) {
+
+	if !val.IsValid() || !val.CanInterface() {
+		return
+	}
+
+	if val.Type() == typ && val.CanAddr() {
+		return *(*T)(unsafe.Pointer(val.UnsafeAddr())), true
+	}
+
+	if typ.Kind() == reflect.Ptr && val.Type() == typ.Elem() {
+
+		if !val.CanAddr() {
+			ptr := reflect.New(val.Type())
+			ptr.Elem().Set(val)
+			val = ptr.Elem()
+		}
+
+		t, ok = val.Addr().Interface().(T)
+		return
+	}
+
+	t, ok = getValue(val).(T)
+	return
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"strings"
 	"sync"
@@ -14,6 +16,8 @@ import (
 
 const (
 	defaultTagName        = "validate"
+	modTagName            = "mod"
+	defaultModifier       = "default"
 	utf8HexComma          = "0x2C"
 	utf8Pipe              = "0x7C"
 	tagSeparator          = ","
@@ -21,8 +25,15 @@ const (
 	tagKeySeparator       = "="
 	structOnlyTag         = "structonly"
 	noStructLevelTag      = "nostructlevel"
+	allErrorsTag          = "allerrors"
+	sensitiveTag          = "sensitive"
+	noTypeRulesTag        = "notyperules"
 	omitempty             = "omitempty"
 	omitnil               = "omitnil"
+	omitzero              = "omitzero"
+	splitTag              = "split"
+	splitTrimTag          = "splittrim"
+	splitWSTag            = "splitws"
 	isdefault             = "isdefault"
 	requiredWithoutAllTag = "required_without_all"
 	requiredWithoutTag    = "required_without"
@@ -42,6 +53,10 @@ const (
 	keysTag               = "keys"
 	endKeysTag            = "endkeys"
 	requiredTag           = "required"
+	unknownKeyTag         = "unknown_key"
+	objectTag             = "object"
+	typeTag               = "type"
+	warnTagPrefix         = "warn:"
 	namespaceSeparator    = "."
 	leftBracket           = "["
 	rightBracket          = "]"
@@ -51,14 +66,32 @@ const (
 )
 
 var (
-	timeDurationType = reflect.TypeOf(time.Duration(0))
-	timeType         = reflect.TypeOf(time.Time{})
-
-	byteSliceType = reflect.TypeOf([]byte{})
+	timeDurationType  = reflect.TypeOf(time.Duration(0))
+	timeType          = reflect.TypeOf(time.Time{})
+	urlType           = reflect.TypeOf(url.URL{})
+	netipAddrType     = reflect.TypeOf(netip.Addr{})
+	netipPrefixType   = reflect.TypeOf(netip.Prefix{})
+	netipAddrPortType = reflect.TypeOf(netip.AddrPort{})
+	zeroerType        = reflect.TypeOf((*zeroer)(nil)).Elem()
+	lennerType        = reflect.TypeOf((*lenner)(nil)).Elem()
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+
+	byteSliceType   = reflect.TypeOf([]byte{})
+	stringSliceType = reflect.TypeOf([]string{})
 
 	defaultCField = &cField{namesEqual: true}
 )
 
+// zeroer is implemented by types which define their own zero value, see the 'omitzero' tag
+type zeroer interface {
+	IsZero() bool
+}
+
+// lenner is implemented by collection types which aren't slices or maps, see the 'len', 'min' and 'max' tags
+type lenner interface {
+	Len() int
+}
+
 // FilterFunc is the type used to filter fields using
 // StructFiltered(...) function.
 // returning true results in the field being filtered/skipped from
@@ -73,28 +106,60 @@ type CustomTypeFunc func(field reflect.Value) interface{}
 // TagNameFunc allows for adding of a custom tag name parser
 type TagNameFunc func(field reflect.StructField) string
 
+type nameScheme struct {
+	name string
+	fn   TagNameFunc
+}
+
+type nameSchemeCtxKey struct{}
+
+type clockCtxKey struct{}
+
 type internalValidationFuncWrapper struct {
 	fn                 FuncCtx
 	runValidationOnNil bool
 }
 
+// ifaceFunc is a CustomTypeFunc registered against an interface, see RegisterCustomTypeFuncByInterface
+type ifaceFunc struct {
+	iface reflect.Type
+	fn    CustomTypeFunc
+}
+
 // Validate contains the validator settings and cache
 type Validate struct {
-	tagName                string
-	pool                   *sync.Pool
-	tagNameFunc            TagNameFunc
-	structLevelFuncs       map[reflect.Type]StructLevelFuncCtx
-	customFuncs            map[reflect.Type]CustomTypeFunc
-	aliases                map[string]string
-	validations            map[string]internalValidationFuncWrapper
-	transTagFunc           map[ut.Translator]map[string]TranslationFunc // map[<locale>]map[<tag>]TranslationFunc
-	rules                  map[reflect.Type]map[string]string
-	tagCache               *tagCache
-	structCache            *structCache
-	hasCustomFuncs         bool
-	hasTagNameFunc         bool
-	requiredStructEnabled  bool
-	privateFieldValidation bool
+	tagName                      string
+	pool                         *sync.Pool
+	tagNameFunc                  TagNameFunc
+	nameSchemes                  []nameScheme
+	structLevelFuncs             map[reflect.Type]StructLevelFuncCtx
+	customFuncs                  map[reflect.Type]CustomTypeFunc
+	ifaceFuncs                   []ifaceFunc
+	customTypeCache              *customTypeCache
+	aliases                      map[string]string
+	validations                  map[string]internalValidationFuncWrapper
+	transTagFunc                 map[ut.Translator]map[string]TranslationFunc // map[<locale>]map[<tag>]TranslationFunc
+	rules                        map[reflect.Type]map[string]string
+	typeRules                    map[reflect.Type]string
+	validationTypes              map[string]reflect.Type // the value types of the validations registered using RegisterValidationFor
+	modifiers                    map[string]ModifierFunc
+	asyncValidations             map[string]*asyncValidation
+	sensitiveTags                map[string]struct{}
+	valueRedactor                func(fe FieldError) interface{}
+	clock                        func() time.Time
+	asyncWorkers                 int
+	tagCache                     *tagCache
+	structCache                  *structCache
+	hasCustomFuncs               bool
+	hasTagNameFunc               bool
+	requiredStructEnabled        bool
+	privateFieldValidation       bool
+	allFieldErrors               bool
+	sortedMapKeys                bool
+	strictMaps                   bool
+	jsonFieldNames               bool
+	structLevelBeforeFields      bool
+	structLevelSkipOnFieldErrors bool
 }
 
 // New returns a new instance of 'validate' with sane defaults.
@@ -114,6 +179,7 @@ func New(options ...Option) *Validate {
 		tagName:     defaultTagName,
 		aliases:     make(map[string]string, len(bakedInAliases)),
 		validations: make(map[string]internalValidationFuncWrapper, len(bakedInValidators)),
+		modifiers:   make(map[string]ModifierFunc, len(bakedInModifiers)),
 		tagCache:    tc,
 		structCache: sc,
 	}
@@ -138,6 +204,10 @@ func New(options ...Option) *Validate {
 		}
 	}
 
+	for k, val := range bakedInModifiers {
+		v.modifiers[k] = val
+	}
+
 	v.pool = &sync.Pool{
 		New: func() interface{} {
 			return &validate{
@@ -192,10 +262,48 @@ func (v Validate) ValidateMapCtx(ctx context.Context, data map[string]interface{
 }
 
 // ValidateMap validates map data from a map of tags
+//
+// See ValidateMapErr which returns ValidationErrors and supports the shapes produced by json.Unmarshal.
 func (v *Validate) ValidateMap(data map[string]interface{}, rules map[string]interface{}) map[string]interface{} {
 	return v.ValidateMapCtx(context.Background(), data, rules)
 }
 
+// ValidateMapErr validates map data, such as decoded using json.Unmarshal, from a map of rules. Each rule is either
+// a string of tags for the value of the key, or a map of rules for a nested object, which may also be a slice of
+// objects in the data eg. []interface{} or []map[string]interface{}. It panics when a rule is neither.
+//
+// The namespaces of the FieldError's are built from the keys eg. 'items[2].name', values which should be objects
+// according to the rules but aren't are reported using the 'object' tag and missing nested objects are skipped,
+// unless using WithStrictMaps.
+//
+// It returns nil or ValidationErrors as error.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
+func (v *Validate) ValidateMapErr(data map[string]interface{}, rules map[string]interface{}) error {
+	return v.ValidateMapErrCtx(context.Background(), data, rules)
+}
+
+// ValidateMapErrCtx does the same as ValidateMapErr and also allows passing of context.Context for contextual
+// validation information.
+func (v *Validate) ValidateMapErrCtx(ctx context.Context, data map[string]interface{}, rules map[string]interface{}) (err error) {
+
+	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
+	vd.top = reflect.ValueOf(data)
+	vd.isPartial = false
+
+	vd.validateMap(ctx, vd.top, data, rules, vd.ns[0:0], vd.actualNs[0:0])
+	if err = vd.runAsync(ctx); err == nil && len(vd.errs) > 0 {
+		err = vd.errs
+	}
+	vd.errs = nil
+	vd.warns = nil
+
+	v.pool.Put(vd)
+
+	return
+}
+
 // RegisterTagNameFunc registers a function to get alternate names for StructFields.
 //
 // eg. to use the names which have been specified for JSON representations of structs, rather than normal Go field names:
@@ -208,11 +316,66 @@ func (v *Validate) ValidateMap(data map[string]interface{}, rules map[string]int
 //	    }
 //	    return name
 //	})
+//
+// See WithJSONFieldNames for using the names exactly as encoding/json does, including embedded structs.
 func (v *Validate) RegisterTagNameFunc(fn TagNameFunc) {
 	v.tagNameFunc = fn
 	v.hasTagNameFunc = true
 }
 
+// RegisterTagNameFuncFor registers a function to get alternate names for StructFields under the given naming scheme,
+// in addition to the one registered using RegisterTagNameFunc, so that the field names can be determined for each of
+// the ways a struct is bound eg. from JSON bodies, form posts and query strings. When the function returns an empty
+// string the field's Go name is used.
+//
+// The names are available from the FieldErrorNames interface of the FieldErrors, or can be used for the namespaces of a
+// single call by passing a context created using ContextWithNameScheme.
+//
+//	validate.RegisterTagNameFuncFor("form", func(fld reflect.StructField) string {
+//	    return fld.Tag.Get("form")
+//	})
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterTagNameFuncFor(scheme string, fn TagNameFunc) {
+
+	for i := 0; i < len(v.nameSchemes); i++ {
+		if v.nameSchemes[i].name == scheme {
+			v.nameSchemes[i].fn = fn
+			return
+		}
+	}
+
+	v.nameSchemes = append(v.nameSchemes, nameScheme{name: scheme, fn: fn})
+}
+
+// ContextWithNameScheme returns a copy of ctx which makes the namespaces and field names of the FieldError's use the
+// names of the given naming scheme, registered using RegisterTagNameFuncFor, when passed to StructCtx and the other
+// context aware methods.
+//
+// NOTE: only the namespace is affected, the struct namespace always uses the field's Go names.
+func ContextWithNameScheme(ctx context.Context, scheme string) context.Context {
+	return context.WithValue(ctx, nameSchemeCtxKey{}, scheme)
+}
+
+// ContextWithClock returns a copy of ctx which makes the validations of times, such as the 'gt' validation of a
+// time.Time and the 'within' validation, use the current time returned by now when passed to StructCtx and the other
+// context aware methods, instead of the one from WithClock.
+func ContextWithClock(ctx context.Context, now func() time.Time) context.Context {
+	return context.WithValue(ctx, clockCtxKey{}, now)
+}
+
+// nameSchemeIndex returns the index of the naming scheme, or -1 when not registered.
+func (v *Validate) nameSchemeIndex(scheme string) int {
+
+	for i := 0; i < len(v.nameSchemes); i++ {
+		if v.nameSchemes[i].name == scheme {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // RegisterValidation adds a validation with the given tag
 //
 // NOTES:
@@ -232,6 +395,52 @@ func (v *Validate) RegisterValidationCtx(tag string, fn FuncCtx, callValidationE
 	return v.registerValidation(tag, fn, false, nilCheckable)
 }
 
+// RegisterValidationErr does the same as RegisterValidationCtx but accepts a FuncErr validation
+// allowing the validation to describe why it failed. The returned error is wrapped into the
+// resulting FieldError, see NewFuncError for attaching key/value details for use in translations.
+// When used within an 'or' tag only the error of the last alternative is wrapped, as the failure
+// is that of the whole tag.
+func (v *Validate) RegisterValidationErr(tag string, fn FuncErr, callValidationEvenIfNull ...bool) error {
+	return v.RegisterValidationCtx(tag, wrapFuncErr(fn), callValidationEvenIfNull...)
+}
+
+// RegisterValidationFor adds a validation with the given tag for values of type T, which are passed to the
+// function along with the tag's param, so there's no need to go through FieldLevel and reflection.
+//
+//	validator.RegisterValidationFor(validate, "currency", func(ctx context.Context, d Decimal, param string) bool {
+//	    return d.Exponent() >= -2
+//	})
+//
+// The field, or its elements when diving, must be of type T or implement T when it's an interface, otherwise
+// building the struct's cache panics. When T is a pointer type, such as *big.Int, fields of the type it points to
+// are also accepted and are passed as a pointer to the value. Values whose type can only be known at runtime, such as interfaces or those
+// converted using RegisterCustomTypeFunc, fail the validation when not of type T, as do unexported fields validated
+// using WithPrivateFieldValidation, whose values aren't exposed. The value is read directly when it's addressable,
+// such as when validating a pointer to a struct, avoiding allocations.
+//
+// NOTES:
+// - if the key already exists, the previous validation function will be replaced.
+// - this method is not thread-safe it is intended that these all be registered prior to any validation
+func RegisterValidationFor[T any](v *Validate, tag string, fn func(ctx context.Context, val T, param string) bool, callValidationEvenIfNull ...bool) error {
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	err := v.RegisterValidationCtx(tag, func(ctx context.Context, fl FieldLevel) bool {
+		val, ok := valueAs[T](fl.Field(), typ)
+		return ok && fn(ctx, val, fl.Param())
+	}, callValidationEvenIfNull...)
+	if err != nil {
+		return err
+	}
+
+	if v.validationTypes == nil {
+		v.validationTypes = make(map[string]reflect.Type)
+	}
+	v.validationTypes[tag] = typ
+
+	return nil
+}
+
 func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool, nilCheckable bool) error {
 	if len(tag) == 0 {
 		return errors.New("function Key cannot be empty")
@@ -246,9 +455,27 @@ func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool, nilC
 		panic(fmt.Sprintf(restrictedTagErr, tag))
 	}
 	v.validations[tag] = internalValidationFuncWrapper{fn: fn, runValidationOnNil: nilCheckable}
+	delete(v.validationTypes, tag)
+	delete(v.asyncValidations, tag)
 	return nil
 }
 
+// RegisterSensitiveTags marks the validations with the given tags as sensitive, when any of them fail the field's value
+// is redacted from the resulting FieldError, see WithValueRedactor. Fields can also be marked as sensitive regardless of
+// the validations that fail by using the 'sensitive' tag.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterSensitiveTags(tags ...string) {
+
+	if v.sensitiveTags == nil {
+		v.sensitiveTags = make(map[string]struct{}, len(tags))
+	}
+
+	for _, tag := range tags {
+		v.sensitiveTags[tag] = struct{}{}
+	}
+}
+
 // RegisterAlias registers a mapping of a single validation tag that
 // defines a common or complex set of validation(s) to simplify adding validation
 // to structs.
@@ -294,9 +521,42 @@ func (v *Validate) RegisterStructValidationCtx(fn StructLevelFuncCtx, types ...i
 	}
 }
 
+// RegisterStructValidationFor registers a struct level validation for the struct type T, which is passed the
+// struct being validated, see RegisterStructValidationCtx. The struct is read directly when it's addressable,
+// avoiding allocations.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func RegisterStructValidationFor[T any](v *Validate, fn func(ctx context.Context, sl StructLevel, val T)) {
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: RegisterStructValidationFor requires a struct type, got '%s'", typ))
+	}
+
+	v.RegisterStructValidationCtx(func(ctx context.Context, sl StructLevel) {
+		if val, ok := valueAs[T](sl.Current(), typ); ok {
+			fn(ctx, sl, val)
+		}
+	}, reflect.Zero(typ).Interface())
+}
+
 // RegisterStructValidationMapRules registers validate map rules.
 // Be aware that map validation rules supersede those defined on a/the struct if present.
 //
+// The keys can also be paths to the fields of nested structs and to the elements of slices, arrays and maps, which
+// only apply when the nested struct is reached through the path, eg.
+//
+//	validate.RegisterStructValidationMapRules(map[string]string{
+//	    "Address.City": "required",        // the City field of the Address field
+//	    "Items":        "min=1",           // the Items field itself
+//	    "Items[*].SKU": "required",        // the SKU field of each of the Items
+//	    "Meta[*]":      "required,max=64", // each of the values of the Meta map
+//	}, Order{})
+//
+// Rules for elements are added after a dive, replacing anything after the dive of the field's own rules. It panics
+// when a path doesn't exist within the type. The rules may include modifiers, which replace those of the field's 'mod'
+// tag, see Normalize.
+//
 // NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
 func (v *Validate) RegisterStructValidationMapRules(rules map[string]string, types ...interface{}) {
 	if v.rules == nil {
@@ -318,10 +578,41 @@ func (v *Validate) RegisterStructValidationMapRules(rules map[string]string, typ
 		if typ.Kind() != reflect.Struct {
 			continue
 		}
+
+		for path := range deepCopyRules {
+			if strings.ContainsAny(path, ".[") {
+				checkRulePath(typ, path)
+			}
+		}
+
 		v.rules[typ] = deepCopyRules
 	}
 }
 
+// RegisterTypeRules registers validation rules which apply wherever a value of the given types is validated, as a
+// struct field, a slice or array element or a map value, including when using Var. The rules are added to the field's
+// own, after any leading omitempty, omitnil, omitzero or required tags, and a dive is added for the elements of slices, arrays
+// and maps when their tag doesn't have one already.
+//
+//	type Email string
+//
+//	validate.RegisterTypeRules("email", Email(""))
+//
+// Individual fields can opt out of the type rules using the 'notyperules' tag. Registering rules for a type again
+// replaces its rules.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterTypeRules(tag string, types ...interface{}) {
+
+	if v.typeRules == nil {
+		v.typeRules = make(map[reflect.Type]string)
+	}
+
+	for _, t := range types {
+		v.typeRules[reflect.TypeOf(t)] = tag
+	}
+}
+
 // RegisterCustomTypeFunc registers a CustomTypeFunc against a number of types
 //
 // NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
@@ -338,6 +629,72 @@ func (v *Validate) RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{
 	v.hasCustomFuncs = true
 }
 
+// RegisterCustomTypeFuncByInterface registers a CustomTypeFunc against a number of interfaces, passed as nil pointers
+// to them, which is used for the types implementing any of the interfaces eg.
+//
+//	validate.RegisterCustomTypeFuncByInterface(func(field reflect.Value) interface{} {
+//	    if v, err := field.Interface().(driver.Valuer).Value(); err == nil {
+//	        return v
+//	    }
+//	    return nil
+//	}, (*driver.Valuer)(nil))
+//
+// The field passed to the function implements the interface, being a pointer to the value when only the pointer
+// implements it. Functions registered against a type using RegisterCustomTypeFunc take precedence, otherwise the
+// first interface registered which the type implements is used.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterCustomTypeFuncByInterface(fn CustomTypeFunc, ifaces ...interface{}) {
+
+	for _, i := range ifaces {
+		typ := reflect.TypeOf(i)
+
+		if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Interface {
+			panic(fmt.Sprintf("validator: interfaces must be passed as nil pointers to them eg. (*driver.Valuer)(nil), got '%v'", typ))
+		}
+
+		v.ifaceFuncs = append(v.ifaceFuncs, ifaceFunc{iface: typ.Elem(), fn: fn})
+	}
+
+	v.customTypeCache = new(customTypeCache)
+	v.customTypeCache.m.Store(make(map[reflect.Type]customTypeMatch))
+	v.hasCustomFuncs = true
+}
+
+// customTypeFunc returns the CustomTypeFunc for the type, registered against the type itself or an interface it
+// implements, and whether the function must be passed a pointer to the value.
+func (v *Validate) customTypeFunc(typ reflect.Type) (CustomTypeFunc, bool) {
+
+	if fn, ok := v.customFuncs[typ]; ok {
+		return fn, false
+	}
+
+	if len(v.ifaceFuncs) == 0 {
+		return nil, false
+	}
+
+	m, ok := v.customTypeCache.Get(typ)
+	if !ok {
+		ptr := reflect.PtrTo(typ)
+
+		for _, f := range v.ifaceFuncs {
+			if typ.Implements(f.iface) {
+				m = customTypeMatch{fn: f.fn}
+				break
+			}
+
+			if typ.Kind() != reflect.Interface && ptr.Implements(f.iface) {
+				m = customTypeMatch{fn: f.fn, addr: true}
+				break
+			}
+		}
+
+		v.customTypeCache.Set(typ, m)
+	}
+
+	return m.fn, m.addr
+}
+
 // RegisterTranslation registers translations against the provided tag.
 func (v *Validate) RegisterTranslation(tag string, trans ut.Translator, registerFn RegisterTranslationsFunc, translationFn TranslationFunc) (err error) {
 
@@ -364,6 +721,8 @@ func (v *Validate) RegisterTranslation(tag string, trans ut.Translator, register
 //
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) Struct(s interface{}) error {
 	return v.StructCtx(context.Background(), s)
 }
@@ -373,8 +732,45 @@ func (v *Validate) Struct(s interface{}) error {
 //
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) StructCtx(ctx context.Context, s interface{}) (err error) {
 
+	errs, _, err := v.structCtx(ctx, s)
+	if err == nil && len(errs) > 0 {
+		err = errs
+	}
+
+	return
+}
+
+// StructResult validates a structs exposed fields, and automatically validates nested structs, unless otherwise specified
+// returning a Result in which the failed validations with warning severity are kept separate from the errors.
+//
+// It returns InvalidValidationError for bad values passed in, otherwise the Result; use Result.Err() to determine
+// whether the struct failed validation as warnings alone never fail it.
+func (v *Validate) StructResult(s interface{}) (*Result, error) {
+	return v.StructResultCtx(context.Background(), s)
+}
+
+// StructResultCtx does the same as StructResult and also allows passing of context.Context for contextual validation
+// information.
+//
+// It returns InvalidValidationError for bad values passed in, otherwise the Result; use Result.Err() to determine
+// whether the struct failed validation as warnings alone never fail it.
+func (v *Validate) StructResultCtx(ctx context.Context, s interface{}) (*Result, error) {
+
+	errs, warns, err := v.structCtx(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Errors: errs, Warnings: warns}, nil
+}
+
+// structCtx validates the struct returning its errors and warnings, see StructCtx and StructResultCtx.
+func (v *Validate) structCtx(ctx context.Context, s interface{}) (errs ValidationErrors, warns ValidationErrors, err error) {
+
 	val := reflect.ValueOf(s)
 	top := val
 
@@ -383,21 +779,22 @@ func (v *Validate) StructCtx(ctx context.Context, s interface{}) (err error) {
 	}
 
 	if val.Kind() != reflect.Struct || val.Type().ConvertibleTo(timeType) {
-		return &InvalidValidationError{Type: reflect.TypeOf(s)}
+		return nil, nil, &InvalidValidationError{Type: reflect.TypeOf(s)}
 	}
 
 	// good to validate
 	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
 	vd.top = top
 	vd.isPartial = false
 	// vd.hasExcludes = false // only need to reset in StructPartial and StructExcept
 
-	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
+	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil, nil)
+	err = vd.runAsync(ctx)
 
-	if len(vd.errs) > 0 {
-		err = vd.errs
-		vd.errs = nil
-	}
+	errs, warns = vd.errs, vd.warns
+	vd.errs = nil
+	vd.warns = nil
 
 	v.pool.Put(vd)
 
@@ -409,6 +806,8 @@ func (v *Validate) StructCtx(ctx context.Context, s interface{}) (err error) {
 //
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) StructFiltered(s interface{}, fn FilterFunc) error {
 	return v.StructFilteredCtx(context.Background(), s, fn)
 }
@@ -419,6 +818,8 @@ func (v *Validate) StructFiltered(s interface{}, fn FilterFunc) error {
 //
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) StructFilteredCtx(ctx context.Context, s interface{}, fn FilterFunc) (err error) {
 	val := reflect.ValueOf(s)
 	top := val
@@ -433,17 +834,18 @@ func (v *Validate) StructFilteredCtx(ctx context.Context, s interface{}, fn Filt
 
 	// good to validate
 	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
 	vd.top = top
 	vd.isPartial = true
 	vd.ffn = fn
 	// vd.hasExcludes = false // only need to reset in StructPartial and StructExcept
 
-	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
-
-	if len(vd.errs) > 0 {
+	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil, nil)
+	if err = vd.runAsync(ctx); err == nil && len(vd.errs) > 0 {
 		err = vd.errs
-		vd.errs = nil
 	}
+	vd.errs = nil
+	vd.warns = nil
 
 	v.pool.Put(vd)
 
@@ -456,6 +858,8 @@ func (v *Validate) StructFilteredCtx(ctx context.Context, s interface{}, fn Filt
 //
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) StructPartial(s interface{}, fields ...string) error {
 	return v.StructPartialCtx(context.Background(), s, fields...)
 }
@@ -467,6 +871,8 @@ func (v *Validate) StructPartial(s interface{}, fields ...string) error {
 //
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields ...string) (err error) {
 	val := reflect.ValueOf(s)
 	top := val
@@ -481,6 +887,7 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 
 	// good to validate
 	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
 	vd.top = top
 	vd.isPartial = true
 	vd.ffn = nil
@@ -528,12 +935,12 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 		}
 	}
 
-	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
-
-	if len(vd.errs) > 0 {
+	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil, nil)
+	if err = vd.runAsync(ctx); err == nil && len(vd.errs) > 0 {
 		err = vd.errs
-		vd.errs = nil
 	}
+	vd.errs = nil
+	vd.warns = nil
 
 	v.pool.Put(vd)
 
@@ -546,6 +953,8 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 //
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) StructExcept(s interface{}, fields ...string) error {
 	return v.StructExceptCtx(context.Background(), s, fields...)
 }
@@ -557,6 +966,8 @@ func (v *Validate) StructExcept(s interface{}, fields ...string) error {
 //
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ...string) (err error) {
 	val := reflect.ValueOf(s)
 	top := val
@@ -571,6 +982,7 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 
 	// good to validate
 	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
 	vd.top = top
 	vd.isPartial = true
 	vd.ffn = nil
@@ -593,12 +1005,12 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 		vd.includeExclude[string(vd.misc)] = struct{}{}
 	}
 
-	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
-
-	if len(vd.errs) > 0 {
+	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil, nil)
+	if err = vd.runAsync(ctx); err == nil && len(vd.errs) > 0 {
 		err = vd.errs
-		vd.errs = nil
 	}
+	vd.errs = nil
+	vd.warns = nil
 
 	v.pool.Put(vd)
 
@@ -618,6 +1030,8 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
 // validate Array, Slice and maps fields which may contain more than one error
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) Var(field interface{}, tag string) error {
 	return v.VarCtx(context.Background(), field, tag)
 }
@@ -636,7 +1050,13 @@ func (v *Validate) Var(field interface{}, tag string) error {
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
 // validate Array, Slice and maps fields which may contain more than one error
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) VarCtx(ctx context.Context, field interface{}, tag string) (err error) {
+	if len(v.typeRules) > 0 && tag != skipValidationTag && !v.hasMarker(tag, noTypeRulesTag) {
+		tag = v.applyTypeRules(reflect.TypeOf(field), tag)
+	}
+
 	if len(tag) == 0 || tag == skipValidationTag {
 		return nil
 	}
@@ -645,14 +1065,15 @@ func (v *Validate) VarCtx(ctx context.Context, field interface{}, tag string) (e
 
 	val := reflect.ValueOf(field)
 	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
 	vd.top = val
 	vd.isPartial = false
 	vd.traverseField(ctx, val, val, vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
-
-	if len(vd.errs) > 0 {
+	if err = vd.runAsync(ctx); err == nil && len(vd.errs) > 0 {
 		err = vd.errs
-		vd.errs = nil
 	}
+	vd.errs = nil
+	vd.warns = nil
 	v.pool.Put(vd)
 	return
 }
@@ -671,6 +1092,8 @@ func (v *Validate) VarCtx(ctx context.Context, field interface{}, tag string) (e
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
 // validate Array, Slice and maps fields which may contain more than one error
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) VarWithValue(field interface{}, other interface{}, tag string) error {
 	return v.VarWithValueCtx(context.Background(), field, other, tag)
 }
@@ -690,21 +1113,28 @@ func (v *Validate) VarWithValue(field interface{}, other interface{}, tag string
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
 // validate Array, Slice and maps fields which may contain more than one error
+//
+// NOTE: failed validations with warning severity aren't returned, see StructResult
 func (v *Validate) VarWithValueCtx(ctx context.Context, field interface{}, other interface{}, tag string) (err error) {
+	if len(v.typeRules) > 0 && tag != skipValidationTag && !v.hasMarker(tag, noTypeRulesTag) {
+		tag = v.applyTypeRules(reflect.TypeOf(field), tag)
+	}
+
 	if len(tag) == 0 || tag == skipValidationTag {
 		return nil
 	}
 	ctag := v.fetchCacheTag(tag)
 	otherVal := reflect.ValueOf(other)
 	vd := v.pool.Get().(*validate)
+	vd.setCallOptions(ctx)
 	vd.top = otherVal
 	vd.isPartial = false
 	vd.traverseField(ctx, otherVal, reflect.ValueOf(field), vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
-
-	if len(vd.errs) > 0 {
+	if err = vd.runAsync(ctx); err == nil && len(vd.errs) > 0 {
 		err = vd.errs
-		vd.errs = nil
 	}
+	vd.errs = nil
+	vd.warns = nil
 	v.pool.Put(vd)
 	return
 }
@@ -5,16 +5,25 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
 	"image/jpeg"
 	"image/png"
+	"math"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -14123,3 +14132,2812 @@ func TestPrivateFieldsStruct(t *testing.T) {
 		Equal(t, len(errs), tc.errorNum)
 	}
 }
+
+func TestRegisterValidationErr(t *testing.T) {
+	type User struct {
+		Password string `validate:"required,password"`
+	}
+
+	validate := New()
+	err := validate.RegisterValidationErr("password", func(ctx context.Context, fl FieldLevel) error {
+		s := fl.Field().String()
+		if len(s) < 8 {
+			return NewFuncError("too short", "min", 8, "actual", len(s))
+		}
+		if !strings.ContainsAny(s, "0123456789") {
+			return NewFuncError("missing digit")
+		}
+		if strings.Contains(s, "password") {
+			return errors.New("too common")
+		}
+		return nil
+	})
+	Equal(t, err, nil)
+
+	err = validate.RegisterValidationErr("", nil)
+	NotEqual(t, err, nil)
+
+	err = validate.Struct(User{Password: "sup3rsecret"})
+	Equal(t, err, nil)
+
+	err = validate.Struct(User{Password: "abc"})
+	NotEqual(t, err, nil)
+	AssertError(t, err, "User.Password", "User.Password", "Password", "Password", "password")
+
+	fe := err.(ValidationErrors)[0]
+	Equal(t, fe.Error(), "Key: 'User.Password' Error:Field validation for 'Password' failed on the 'password' tag: too short")
+	Equal(t, fe.(FieldErrorDetails).Details(), map[string]interface{}{"min": 8, "actual": 3})
+
+	var fnErr *FuncError
+	Equal(t, errors.As(fe, &fnErr), true)
+	Equal(t, fnErr.Reason, "too short")
+
+	err = validate.Struct(User{Password: "abcdefghij"})
+	NotEqual(t, err, nil)
+	fe = err.(ValidationErrors)[0]
+	Equal(t, errors.Unwrap(fe).Error(), "missing digit")
+	Equal(t, len(fe.(FieldErrorDetails).Details()), 0)
+
+	err = validate.Struct(User{Password: "password1"})
+	NotEqual(t, err, nil)
+	fe = err.(ValidationErrors)[0]
+	Equal(t, errors.Unwrap(fe).Error(), "too common")
+	Equal(t, fe.(FieldErrorDetails).Details() == nil, true)
+
+	// failures of regular validations carry no wrapped error
+	err = validate.Struct(User{})
+	NotEqual(t, err, nil)
+	fe = err.(ValidationErrors)[0]
+	Equal(t, fe.Tag(), "required")
+	Equal(t, errors.Unwrap(fe), nil)
+
+	// or'd validations carry the error of their last alternative only
+	err = validate.Var("abc", "password|email")
+	NotEqual(t, err, nil)
+	Equal(t, errors.Unwrap(err.(ValidationErrors)[0]), nil)
+
+	err = validate.Var("abc", "email|password")
+	NotEqual(t, err, nil)
+	Equal(t, errors.Unwrap(err.(ValidationErrors)[0]).Error(), "too short")
+
+	err = validate.Var("abc@example.com", "email|password")
+	Equal(t, err, nil)
+
+	PanicMatches(t, func() { NewFuncError("bad", "key") }, "NewFuncError: odd number of key/value arguments")
+
+	eng := en.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	err = validate.RegisterTranslation("password", trans, func(ut ut.Translator) error {
+		return ut.Add("password", "{0} is invalid: {1}", false)
+	}, func(ut ut.Translator, fe FieldError) string {
+		reason := errors.Unwrap(fe).Error()
+		if min, ok := fe.(FieldErrorDetails).Details()["min"]; ok {
+			reason = fmt.Sprintf("must be at least %d characters", min)
+		}
+		t, _ := ut.T(fe.Tag(), fe.Field(), reason)
+		return t
+	})
+	Equal(t, err, nil)
+
+	err = validate.Struct(User{Password: "abc"})
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Translate(trans), "Password is invalid: must be at least 8 characters")
+
+	err = validate.Struct(User{Password: "abcdefghij"})
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Translate(trans), "Password is invalid: missing digit")
+}
+
+func TestValidationErrorsIs(t *testing.T) {
+	type Inner struct {
+		Email string `validate:"email"`
+	}
+
+	type Test struct {
+		Name  string `validate:"required"`
+		Color string `validate:"iscolor"`
+		Inner Inner
+	}
+
+	validate := New()
+
+	err := validate.Struct(Test{Color: "#fff", Inner: Inner{Email: "not an email"}})
+	NotEqual(t, err, nil)
+	Equal(t, errors.Is(err, ErrRequired), true)
+	Equal(t, errors.Is(err, ErrEmail), true)
+	Equal(t, errors.Is(err, ErrMin), false)
+	Equal(t, errors.Is(err, TagError("email")), true)
+
+	wrapped := fmt.Errorf("binding body: %w", err)
+	Equal(t, errors.Is(wrapped, ErrRequired), true)
+	Equal(t, errors.Is(wrapped, ErrEmail), true)
+	Equal(t, errors.Is(wrapped, ErrGt), false)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs.Unwrap()), 2)
+	Equal(t, errs.Unwrap()[0], error(errs[0]))
+	Equal(t, errors.Is(errs[0], ErrRequired), true)
+	Equal(t, errors.Is(errs[0], ErrEmail), false)
+
+	// aliases match on both the alias and the actual tag
+	err = validate.Struct(Test{Name: "name", Color: "nope"})
+	NotEqual(t, err, nil)
+	Equal(t, errors.Is(err, TagError("iscolor")), true)
+	Equal(t, errors.Is(err, TagError("hexcolor|rgb|rgba|hsl|hsla")), true)
+	Equal(t, ErrRequired.Error(), "validator: failed on the 'required' tag")
+
+	// custom tags
+	err = validate.RegisterValidation("even", func(fl FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	})
+	Equal(t, err, nil)
+
+	err = validate.Var(3, "even")
+	NotEqual(t, err, nil)
+	Equal(t, errors.Is(fmt.Errorf("%w", err), TagError("even")), true)
+
+	// invalid validation errors
+	err = validate.Struct(nil)
+	NotEqual(t, err, nil)
+	Equal(t, errors.Is(err, ErrInvalidValidation), true)
+	Equal(t, errors.Is(fmt.Errorf("wrapped: %w", err), ErrInvalidValidation), true)
+	Equal(t, errors.Is(err, ErrRequired), false)
+
+	err = validate.Struct(1)
+	NotEqual(t, err, nil)
+	Equal(t, errors.Is(err, &InvalidValidationError{Type: reflect.TypeOf(1)}), true)
+	Equal(t, errors.Is(err, &InvalidValidationError{Type: reflect.TypeOf("")}), false)
+	Equal(t, errors.Is(ErrRequired, ErrInvalidValidation), false)
+}
+
+func TestWarningSeverity(t *testing.T) {
+	type Account struct {
+		Name       string  `validate:"required,max=100,warn:max=10"`
+		Legacy     string  `validate:"warn:isdefault"`
+		Color      string  `validate:"warn:iscolor"`
+		Contact    string  `validate:"warn:email|e164"`
+		PasswordAt int     `validate:"gte=0,warn:lte=90,lte=365"`
+		Nickname   *string `validate:"warn:required"`
+	}
+
+	validate := New()
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		acc := sl.Current().Interface().(Account)
+		if acc.Name == "root" {
+			sl.(StructLevelWarnings).ReportWarning(acc.Name, "Name", "Name", "reserved", "")
+		}
+	}, Account{})
+
+	nick := "nick"
+	valid := Account{Name: "short", Color: "#fff", Contact: "a@b.co", PasswordAt: 30, Nickname: &nick}
+
+	res, err := validate.StructResult(valid)
+	Equal(t, err, nil)
+	Equal(t, res.Err(), nil)
+	Equal(t, len(res.Errors), 0)
+	Equal(t, len(res.Warnings), 0)
+
+	acc := Account{Name: "a rather long name", Legacy: "set", Color: "nope", Contact: "nope", PasswordAt: 100}
+
+	// warnings never fail validation
+	err = validate.Struct(acc)
+	Equal(t, err, nil)
+
+	res, err = validate.StructResult(acc)
+	Equal(t, err, nil)
+	Equal(t, res.Err(), nil)
+	Equal(t, len(res.Errors), 0)
+	Equal(t, len(res.Warnings), 6)
+	AssertError(t, res.Warnings, "Account.Name", "Account.Name", "Name", "Name", "max")
+	AssertError(t, res.Warnings, "Account.Legacy", "Account.Legacy", "Legacy", "Legacy", "isdefault")
+	AssertError(t, res.Warnings, "Account.Color", "Account.Color", "Color", "Color", "iscolor")
+	AssertError(t, res.Warnings, "Account.Contact", "Account.Contact", "Contact", "Contact", "email|e164")
+	AssertError(t, res.Warnings, "Account.PasswordAt", "Account.PasswordAt", "PasswordAt", "PasswordAt", "lte")
+	AssertError(t, res.Warnings, "Account.Nickname", "Account.Nickname", "Nickname", "Nickname", "required")
+
+	for _, fe := range res.Warnings {
+		Equal(t, fe.(FieldErrorSeverity).Severity(), SeverityWarning)
+	}
+
+	// warnings don't short circuit the remaining validations
+	acc.PasswordAt = 400
+	acc.Name = "root"
+
+	res, err = validate.StructResult(&acc)
+	Equal(t, err, nil)
+	NotEqual(t, res.Err(), nil)
+	Equal(t, len(res.Errors), 1)
+	AssertError(t, res.Errors, "Account.PasswordAt", "Account.PasswordAt", "PasswordAt", "PasswordAt", "lte")
+	Equal(t, res.Errors[0].(FieldErrorSeverity).Severity(), SeverityError)
+	Equal(t, res.Errors[0].Param(), "365")
+	AssertError(t, res.Warnings, "Account.Name", "Account.Name", "Name", "Name", "reserved")
+
+	err = validate.Struct(&acc)
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 1)
+
+	_, err = validate.StructResult(nil)
+	NotEqual(t, err, nil)
+
+	Equal(t, SeverityError.String(), "error")
+	Equal(t, SeverityWarning.String(), "warning")
+	Equal(t, Severity(10).String(), "unknown")
+
+	// JSON & translations
+	res, _ = validate.StructResult(Account{Name: "some name", Color: "#fff", Contact: "a@b.co", PasswordAt: 400, Nickname: &nick})
+
+	b, err := json.Marshal(res)
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"errors":[{"namespace":"Account.PasswordAt","field":"PasswordAt","tag":"lte","param":"365","value":400,"severity":"error"}],`+
+		`"warnings":[{"namespace":"Account.PasswordAt","field":"PasswordAt","tag":"lte","param":"90","value":400,"severity":"warning"}]}`)
+
+	// the JSON representation of ValidationErrors themselves is unchanged
+	b, err = json.Marshal(res.Errors)
+	Equal(t, err, nil)
+	Equal(t, string(b), `[{}]`)
+
+	b, err = json.Marshal(&Result{})
+	Equal(t, err, nil)
+	Equal(t, string(b), `{}`)
+
+	eng := en.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	err = validate.RegisterTranslation("lte", trans, func(ut ut.Translator) error {
+		return ut.Add("lte", "{0} must be {1} or less", false)
+	}, func(ut ut.Translator, fe FieldError) string {
+		t, _ := ut.T(fe.Tag(), fe.Field(), fe.Param())
+		return t
+	})
+	Equal(t, err, nil)
+
+	tr := res.Translate(trans)
+	Equal(t, tr.Errors["Account.PasswordAt"], "PasswordAt must be 365 or less")
+	Equal(t, tr.Warnings["Account.PasswordAt"], "PasswordAt must be 90 or less")
+
+	b, err = json.Marshal(tr)
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"errors":{"Account.PasswordAt":"PasswordAt must be 365 or less"},"warnings":{"Account.PasswordAt":"PasswordAt must be 90 or less"}}`)
+}
+
+func TestWarningSeverityNilPointer(t *testing.T) {
+	type P struct {
+		Opt *string     `validate:"warn:max=50,required"`
+		Any interface{} `validate:"warn:required,warn:min=1"`
+	}
+
+	validate := New()
+
+	err := validate.Struct(P{})
+	NotEqual(t, err, nil)
+	AssertError(t, err, "P.Opt", "P.Opt", "Opt", "Opt", "required")
+	Equal(t, len(err.(ValidationErrors)), 1)
+
+	res, err := validate.StructResult(P{})
+	Equal(t, err, nil)
+	Equal(t, len(res.Errors), 1)
+	Equal(t, len(res.Warnings), 3)
+	AssertError(t, res.Warnings, "P.Opt", "P.Opt", "Opt", "Opt", "max")
+	AssertDeepError(t, res.Warnings, "P.Any", "P.Any", "Any", "Any", "min", "min")
+
+	s := "set"
+	err = validate.Struct(P{Opt: &s, Any: "x"})
+	Equal(t, err, nil)
+}
+
+func TestAllFieldErrors(t *testing.T) {
+	type Inner struct {
+		Name string `validate:"required"`
+	}
+
+	type Test struct {
+		Password string   `validate:"min=8,containsany=0123456789,excludes=pass"`
+		Marked   string   `validate:"allerrors,min=8,containsany=0123456789,excludes=pass"`
+		Optional string   `validate:"omitempty,min=8,containsany=0123456789"`
+		Required string   `validate:"required,min=8,containsany=0123456789"`
+		Tags     []string `validate:"min=2,max=3,dive,alpha"`
+		Or       string   `validate:"email|e164,max=3"`
+		Inner    *Inner   `validate:"required,allerrors"`
+	}
+
+	tst := Test{Password: "pass", Marked: "pass", Tags: []string{"1"}, Or: "nope", Inner: &Inner{}}
+
+	validate := New()
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 8)
+	AssertDeepError(t, errs, "Test.Password", "Test.Password", "Password", "Password", "min", "min")
+	AssertDeepError(t, errs, "Test.Marked", "Test.Marked", "Marked", "Marked", "min", "min")
+	AssertDeepError(t, errs, "Test.Marked", "Test.Marked", "Marked", "Marked", "containsany", "containsany")
+	AssertDeepError(t, errs, "Test.Marked", "Test.Marked", "Marked", "Marked", "excludes", "excludes")
+	AssertDeepError(t, errs, "Test.Required", "Test.Required", "Required", "Required", "required", "required")
+	AssertDeepError(t, errs, "Test.Tags", "Test.Tags", "Tags", "Tags", "min", "min")
+	AssertDeepError(t, errs, "Test.Or", "Test.Or", "Or", "Or", "email|e164", "email|e164")
+	AssertDeepError(t, errs, "Test.Inner.Name", "Test.Inner.Name", "Name", "Name", "required", "required")
+
+	validate = New(WithAllFieldErrors())
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 11)
+	AssertDeepError(t, errs, "Test.Password", "Test.Password", "Password", "Password", "min", "min")
+	AssertDeepError(t, errs, "Test.Password", "Test.Password", "Password", "Password", "containsany", "containsany")
+	AssertDeepError(t, errs, "Test.Password", "Test.Password", "Password", "Password", "excludes", "excludes")
+	AssertDeepError(t, errs, "Test.Or", "Test.Or", "Or", "Or", "max", "max")
+
+	// required still short circuits, and failures stop the dive
+	Equal(t, errs[6].Tag(), "required")
+	Equal(t, errs[6].Namespace(), "Test.Required")
+	Equal(t, errs[7].Namespace(), "Test.Tags")
+	Equal(t, errs[7].Tag(), "min")
+	Equal(t, errs[8].Namespace(), "Test.Or")
+
+	tst = Test{Password: "password1", Marked: "password1", Required: "password1", Tags: []string{"1", "2"}, Or: "a@b.co", Inner: &Inner{Name: "name"}}
+
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertDeepError(t, errs, "Test.Password", "Test.Password", "Password", "Password", "excludes", "excludes")
+	AssertDeepError(t, errs, "Test.Marked", "Test.Marked", "Marked", "Marked", "excludes", "excludes")
+	AssertDeepError(t, errs, "Test.Tags[0]", "Test.Tags[0]", "Tags[0]", "Tags[0]", "alpha", "alpha")
+	AssertDeepError(t, errs, "Test.Tags[1]", "Test.Tags[1]", "Tags[1]", "Tags[1]", "alpha", "alpha")
+	AssertDeepError(t, errs, "Test.Or", "Test.Or", "Or", "Or", "max", "max")
+
+	// failing field level validations on a struct stop validation of its fields
+	type Outer struct {
+		Inner Inner `validate:"allerrors,isdefault,required"`
+	}
+
+	err = New(WithRequiredStructEnabled()).Struct(Outer{Inner: Inner{Name: "name"}})
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 1)
+	AssertError(t, err, "Outer.Inner", "Outer.Inner", "Inner", "Inner", "isdefault")
+
+	err = validate.Var("a", "min=2,alpha,numeric")
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 2)
+
+	PanicMatches(t, func() { _ = validate.RegisterValidation(allErrorsTag, hasValue) }, fmt.Sprintf(restrictedTagErr, allErrorsTag))
+}
+
+func TestSensitiveValueRedaction(t *testing.T) {
+	type Test struct {
+		Password string   `validate:"sensitive,min=12"`
+		Tokens   []string `validate:"sensitive,dive,min=3"`
+		Secret   *string  `validate:"sensitive,required"`
+		Marked   *string  `validate:"allerrors,required"`
+		Card     string   `validate:"credit_card"`
+		Token    string   `validate:"jwt"`
+		Name     string   `validate:"min=5"`
+	}
+
+	tst := Test{Password: "hunter2", Tokens: []string{"ab"}, Card: "1234", Token: "abc", Name: "Joe"}
+
+	validate := New()
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 7)
+	AssertError(t, errs, "Test.Password", "Test.Password", "Password", "Password", "min")
+	AssertError(t, errs, "Test.Tokens[0]", "Test.Tokens[0]", "Tokens[0]", "Tokens[0]", "min")
+	AssertError(t, errs, "Test.Secret", "Test.Secret", "Secret", "Secret", "required")
+	AssertError(t, errs, "Test.Marked", "Test.Marked", "Marked", "Marked", "required")
+	Equal(t, errs[0].Value(), nil)
+	Equal(t, errs[1].Value(), nil)
+	Equal(t, errs[2].Value(), nil)
+	Equal(t, errs[4].Value(), "1234")
+	Equal(t, errs[5].Value(), "abc")
+	Equal(t, errs[6].Value(), "Joe")
+
+	b, err := json.Marshal(&Result{Errors: errs[:1]})
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"errors":[{"namespace":"Test.Password","field":"Password","tag":"min","param":"12","severity":"error"}]}`)
+
+	validate = New(WithSensitiveTagRedaction(), WithValueRedactor(func(fe FieldError) interface{} { return "****" }))
+	validate.RegisterSensitiveTags("min")
+
+	errs = validate.Struct(tst).(ValidationErrors)
+	Equal(t, len(errs), 7)
+	Equal(t, errs[0].Value(), "****")
+	Equal(t, errs[1].Value(), "****")
+	Equal(t, errs[2].Value(), "****")
+	Equal(t, errs[4].Value(), "****")
+	Equal(t, errs[5].Value(), "****")
+	Equal(t, errs[6].Value(), "****")
+
+	err = validate.Var("4111", "credit_card")
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Value(), "****")
+
+	validate = New()
+	validate.RegisterSensitiveTags("pin")
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		sl.ReportError(sl.Current().Interface().(Test).Name, "Name", "Name", "pin", "")
+	}, Test{})
+
+	errs = validate.StructPartial(tst, "Name").(ValidationErrors)
+	Equal(t, len(errs), 2)
+	Equal(t, errs[0].Value(), "Joe")
+	Equal(t, errs[1].Tag(), "pin")
+	Equal(t, errs[1].Value(), nil)
+
+	PanicMatches(t, func() { _ = validate.RegisterValidation(sensitiveTag, hasValue) }, fmt.Sprintf(restrictedTagErr, sensitiveTag))
+}
+
+func TestMarkerPosition(t *testing.T) {
+	type Email string
+
+	type Test struct {
+		Password string            `validate:"min=12,sensitive"`
+		Tokens   []string          `validate:"dive,min=3,sensitive"`
+		ByName   map[string]string `validate:"dive,keys,min=3,endkeys,sensitive"`
+		Marked   string            `validate:"min=8,containsany=0123456789,allerrors"`
+		Secret   string            `validate:"secret"`
+		Email    Email             `validate:"max=3,unchecked"`
+	}
+
+	validate := New()
+	validate.RegisterAlias("secret", "len=4,sensitive")
+	validate.RegisterAlias("unchecked", "notyperules")
+	validate.RegisterTypeRules("email", Email(""))
+
+	tst := Test{
+		Password: "hunter2",
+		Tokens:   []string{"ab"},
+		ByName:   map[string]string{"ab": "x"},
+		Marked:   "pass",
+		Secret:   "hunter2",
+		Email:    "nope",
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 7)
+	AssertError(t, errs, "Test.Password", "Test.Password", "Password", "Password", "min")
+	AssertError(t, errs, "Test.Tokens[0]", "Test.Tokens[0]", "Tokens[0]", "Tokens[0]", "min")
+	AssertError(t, errs, "Test.ByName[ab]", "Test.ByName[ab]", "ByName[ab]", "ByName[ab]", "min")
+	AssertDeepError(t, errs, "Test.Marked", "Test.Marked", "Marked", "Marked", "min", "min")
+	AssertDeepError(t, errs, "Test.Marked", "Test.Marked", "Marked", "Marked", "containsany", "containsany")
+	AssertError(t, errs, "Test.Secret", "Test.Secret", "Secret", "Secret", "secret")
+	AssertError(t, errs, "Test.Email", "Test.Email", "Email", "Email", "max")
+
+	// the markers apply to all of the field's validations wherever they appear
+	Equal(t, errs[0].Value(), nil)
+	Equal(t, errs[1].Value(), nil)
+	Equal(t, errs[2].Value(), nil)
+	Equal(t, errs[3].Value(), "pass")
+	Equal(t, errs[5].Value(), nil)
+	Equal(t, errs[6].Value(), Email("nope"))
+
+	err = validate.Var("hunter2", "min=12,sensitive")
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Value(), nil)
+}
+
+func TestSortedMapKeys(t *testing.T) {
+	type Key struct {
+		A int
+	}
+
+	type Test struct {
+		Ints    map[int]string     `validate:"dive,required"`
+		Strings map[string]string  `validate:"dive,keys,len=1,endkeys,required"`
+		Structs map[Key]string     `validate:"dive,required"`
+		Floats  map[float64]string `validate:"dive,required"`
+	}
+
+	tst := Test{
+		Ints:    map[int]string{10: "", 2: "", -1: "", 7: ""},
+		Strings: map[string]string{"c": "", "a": "", "b": "", "dd": "ok"},
+		Structs: map[Key]string{{A: 3}: "", {A: 1}: "", {A: 2}: ""},
+		Floats:  map[float64]string{1.5: "", -2: "", 0: ""},
+	}
+
+	expected := []string{
+		"Test.Ints[-1]", "Test.Ints[2]", "Test.Ints[7]", "Test.Ints[10]",
+		"Test.Strings[a]", "Test.Strings[b]", "Test.Strings[c]", "Test.Strings[dd]",
+		"Test.Structs[{1}]", "Test.Structs[{2}]", "Test.Structs[{3}]",
+		"Test.Floats[-2]", "Test.Floats[0]", "Test.Floats[1.5]",
+	}
+
+	validate := New(WithSortedMapKeys())
+
+	for i := 0; i < 10; i++ {
+		err := validate.Struct(tst)
+		NotEqual(t, err, nil)
+
+		errs := err.(ValidationErrors)
+		Equal(t, len(errs), len(expected))
+
+		for j := 0; j < len(errs); j++ {
+			Equal(t, errs[j].Namespace(), expected[j])
+		}
+	}
+}
+
+func TestValidationErrorsSort(t *testing.T) {
+	type Item struct {
+		Name string `validate:"required"`
+		SKU  string `validate:"required,len=8"`
+	}
+
+	type Test struct {
+		Name  string `validate:"allerrors,min=5,alpha"`
+		Items []Item `validate:"dive"`
+		Age   int    `validate:"gte=18"`
+	}
+
+	tst := Test{Name: "a1", Items: make([]Item, 11), Age: 1}
+	tst.Items[2].SKU = "x"
+
+	err := New().Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 25)
+	Equal(t, errs[0].Namespace(), "Test.Name")
+	Equal(t, errs[24].Namespace(), "Test.Age")
+
+	errs.Sort()
+	Equal(t, errs[0].Namespace(), "Test.Age")
+	Equal(t, errs[1].Namespace(), "Test.Items[0].Name")
+	Equal(t, errs[2].Namespace(), "Test.Items[0].SKU")
+	Equal(t, errs[5].Namespace(), "Test.Items[2].Name")
+	Equal(t, errs[6].Namespace(), "Test.Items[2].SKU")
+	Equal(t, errs[6].Tag(), "len")
+	Equal(t, errs[21].Namespace(), "Test.Items[10].Name")
+	Equal(t, errs[22].Namespace(), "Test.Items[10].SKU")
+	Equal(t, errs[23].Namespace(), "Test.Name")
+	Equal(t, errs[23].Tag(), "min")
+	Equal(t, errs[24].Namespace(), "Test.Name")
+	Equal(t, errs[24].Tag(), "alpha")
+
+	Equal(t, comparePath("A[9]", "A[10]"), -1)
+	Equal(t, comparePath("A[010]", "A[10]"), 0)
+	Equal(t, comparePath("A[1].B", "A[1]"), 1)
+	Equal(t, comparePath("A1", "A"), 1)
+	Equal(t, comparePath("B", "A[1]"), 1)
+}
+
+func TestCollector(t *testing.T) {
+	type Body struct {
+		Name  string `validate:"required"`
+		Email string `validate:"required,email"`
+	}
+
+	validate := New()
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		if sl.Current().Interface().(Body).Name == "admin" {
+			sl.ReportError("admin", "Name", "Name", "reserved", "")
+		}
+	}, Body{})
+
+	ctx := context.Background()
+
+	var c Collector
+	Equal(t, c.Err(), nil)
+	Equal(t, len(c.Errors()), 0)
+
+	Equal(t, c.Add("query.page", validate.Var(0, "min=1")), nil)
+	Equal(t, c.Add("query.tags", validate.Var([]string{"a", ""}, "dive,required")), nil)
+	Equal(t, c.Add("", validate.StructCtx(ContextWithRootName(ctx, "body"), Body{Name: "admin", Email: "nope"})), nil)
+	Equal(t, c.Add("headers", fmt.Errorf("wrapped: %w", validate.Var("", "required"))), nil)
+	Equal(t, c.Add("", nil), nil)
+
+	// duplicates are only collected once
+	Equal(t, c.Add("query.page", validate.Var(0, "min=1")), nil)
+
+	invalid := validate.Struct(nil)
+	Equal(t, c.Add("body", invalid), invalid)
+
+	mapErrs := validate.ValidateMap(
+		map[string]interface{}{"id": "", "meta": map[string]interface{}{"count": 0}},
+		map[string]interface{}{"id": "required", "meta": map[string]interface{}{"count": "min=1"}},
+	)
+	Equal(t, c.AddMap("form", mapErrs), nil)
+
+	err := c.Err()
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 7)
+	AssertError(t, errs, "query.page", "query.page", "page", "page", "min")
+	AssertError(t, errs, "query.tags[1]", "query.tags[1]", "tags[1]", "tags[1]", "required")
+	AssertError(t, errs, "body.Email", "Body.Email", "Email", "Email", "email")
+	AssertDeepError(t, errs, "body.Name", "Body.Name", "Name", "Name", "reserved", "reserved")
+	AssertError(t, errs, "headers", "headers", "headers", "headers", "required")
+	AssertError(t, errs, "form.id", "form.id", "id", "id", "required")
+	AssertError(t, errs, "form.meta.count", "form.meta.count", "count", "count", "min")
+
+	// prefixes are prepended to struct namespaces
+	c = Collector{}
+	Equal(t, c.Add("body", validate.Struct(Body{Name: "a", Email: "a@b.co"})), nil)
+	Equal(t, c.Add("body", validate.StructCtx(ContextWithRootName(ctx, ""), Body{Email: "a@b.co"})), nil)
+
+	errs = c.Errors()
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "body.Name", "body.Body.Name", "Name", "Name", "required")
+
+	en := en.New()
+	uni := ut.New(en, en)
+	trans, _ := uni.GetTranslator("en")
+
+	err = validate.RegisterTranslation("required", trans,
+		func(ut ut.Translator) error {
+			return ut.Add("required", "{0} is a required field", false)
+		}, func(ut ut.Translator, fe FieldError) string {
+			t, _ := ut.T(fe.Tag(), fe.Field())
+			return t
+		})
+	Equal(t, err, nil)
+
+	Equal(t, errs.Translate(trans), ValidationErrorsTranslations{"body.Name": "Name is a required field"})
+
+	errs = validate.StructPartialCtx(ContextWithRootName(ctx, "body"), Body{}, "Name").(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "body.Name", "Body.Name", "Name", "Name", "required")
+}
+
+func TestNameSchemes(t *testing.T) {
+	type Item struct {
+		SKU string `json:"sku" form:"item_sku" validate:"required"`
+	}
+
+	type Address struct {
+		City string `json:"city" validate:"required"`
+	}
+
+	type User struct {
+		FirstName string            `json:"first_name" form:"fname" validate:"required"`
+		Address   Address           `json:"address" form:"addr"`
+		Items     []Item            `json:"items" form:"item" validate:"dive"`
+		Tags      map[string]string `json:"tags" validate:"dive,required"`
+		Age       int               `json:"age" form:"user_age"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFuncFor("json", func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+	validate.RegisterTagNameFuncFor("form", func(fld reflect.StructField) string {
+		return fld.Tag.Get("form")
+	})
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		if sl.Current().Interface().(User).Age < 18 {
+			sl.ReportError(sl.Current().Interface().(User).Age, "Age", "Age", "adult", "")
+		}
+	}, User{})
+
+	tst := User{Items: []Item{{SKU: "a"}, {}}, Tags: map[string]string{"color": ""}}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+
+	fe := errs[0]
+	Equal(t, fe.Namespace(), "User.FirstName")
+	Equal(t, fe.(FieldErrorNames).FieldAs("json"), "first_name")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("json"), "User.first_name")
+	Equal(t, fe.(FieldErrorNames).FieldAs("form"), "fname")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("form"), "User.fname")
+	Equal(t, fe.(FieldErrorNames).FieldAs("yaml"), "FirstName")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("yaml"), "User.FirstName")
+
+	fe = errs[1]
+	Equal(t, fe.Namespace(), "User.Address.City")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("json"), "User.address.city")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("form"), "User.addr.City")
+
+	fe = errs[2]
+	Equal(t, fe.Namespace(), "User.Items[1].SKU")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("json"), "User.items[1].sku")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("form"), "User.item[1].item_sku")
+	Equal(t, fe.(FieldErrorNames).FieldAs("form"), "item_sku")
+
+	fe = errs[3]
+	Equal(t, fe.Namespace(), "User.Tags[color]")
+	Equal(t, fe.(FieldErrorNames).FieldAs("json"), "tags[color]")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("json"), "User.tags[color]")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("form"), "User.Tags[color]")
+
+	fe = errs[4]
+	Equal(t, fe.Tag(), "adult")
+	Equal(t, fe.(FieldErrorNames).NamespaceAs("form"), "User.user_age")
+
+	// selecting the scheme for a single call
+	ctx := ContextWithNameScheme(ContextWithRootName(context.Background(), "body"), "json")
+
+	err = validate.StructCtx(ctx, tst)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertError(t, errs, "body.first_name", "User.FirstName", "first_name", "FirstName", "required")
+	AssertError(t, errs, "body.address.city", "User.Address.City", "city", "City", "required")
+	AssertError(t, errs, "body.items[1].sku", "User.Items[1].SKU", "sku", "SKU", "required")
+	AssertError(t, errs, "body.tags[color]", "User.Tags[color]", "tags[color]", "Tags[color]", "required")
+	AssertError(t, errs, "body.age", "User.Age", "age", "Age", "adult")
+	Equal(t, errs[2].(FieldErrorNames).NamespaceAs("form"), "body.item[1].item_sku")
+
+	var c Collector
+	Equal(t, c.Add("request", err), nil)
+	Equal(t, c.Errors()[2].Namespace(), "request.body.items[1].sku")
+	Equal(t, c.Errors()[2].(FieldErrorNames).NamespaceAs("form"), "request.body.item[1].item_sku")
+
+	err = validate.Var([]Item{{}}, "dive")
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Namespace(), "[0].SKU")
+	Equal(t, err.(ValidationErrors)[0].(FieldErrorNames).NamespaceAs("form"), "[0].item_sku")
+
+	// without any naming schemes the default names are returned
+	err = New().Struct(tst)
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].(FieldErrorNames).FieldAs("json"), "FirstName")
+	Equal(t, err.(ValidationErrors)[0].(FieldErrorNames).NamespaceAs("json"), "User.FirstName")
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	type Meta struct {
+		Version int `json:"version" validate:"min=1"`
+	}
+
+	type Base struct {
+		ID string `json:"id" validate:"required"`
+		Meta
+	}
+
+	type Audit struct {
+		By string `json:"by" validate:"required"`
+	}
+
+	type User struct {
+		*Base
+		Audit     `json:"audit"`
+		FirstName string `json:"first_name,omitempty" validate:"required"`
+		LastName  string `json:",omitempty" validate:"required"`
+		Password  string `json:"-" validate:"required"`
+		Dash      string `json:"-," validate:"required"`
+		Invalid   string `json:"in\\valid" validate:"required"`
+	}
+
+	validate := New(WithJSONFieldNames())
+
+	err := validate.Struct(User{Base: &Base{}})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 7)
+	AssertError(t, errs, "User.id", "User.Base.ID", "id", "ID", "required")
+	AssertError(t, errs, "User.version", "User.Base.Meta.Version", "version", "Version", "min")
+	AssertError(t, errs, "User.audit.by", "User.Audit.By", "by", "By", "required")
+	AssertError(t, errs, "User.first_name", "User.FirstName", "first_name", "FirstName", "required")
+	AssertError(t, errs, "User.LastName", "User.LastName", "LastName", "LastName", "required")
+	AssertError(t, errs, "User.-", "User.Dash", "-", "Dash", "required")
+	AssertError(t, errs, "User.Invalid", "User.Invalid", "Invalid", "Invalid", "required")
+
+	// fields skipped by encoding/json aren't validated
+	for _, fe := range errs {
+		NotEqual(t, fe.StructField(), "Password")
+	}
+
+	// struct filtering still uses the struct namespace
+	err = validate.StructPartial(User{Base: &Base{}}, "Base.ID")
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 1)
+	AssertError(t, err, "User.id", "User.Base.ID", "id", "ID", "required")
+
+	// naming schemes skip the promoted embedded structs as well
+	validate = New(WithJSONFieldNames())
+	validate.RegisterTagNameFuncFor("form", func(fld reflect.StructField) string {
+		return strings.ToUpper(fld.Name)
+	})
+
+	err = validate.Struct(User{Base: &Base{ID: "1"}})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, errs[0].Namespace(), "User.version")
+	Equal(t, errs[0].(FieldErrorNames).NamespaceAs("form"), "User.VERSION")
+	Equal(t, errs[1].(FieldErrorNames).NamespaceAs("form"), "User.AUDIT.BY")
+}
+
+func TestJSONFieldNamesDominance(t *testing.T) {
+	type Inner struct {
+		ID  string `json:"id" validate:"required"`
+		Ref string `validate:"required"`
+	}
+
+	type Contact struct {
+		Name  string `json:"name" validate:"required"`
+		Email string `json:"email" validate:"required"`
+		Phone string `validate:"required"`
+		Inner
+	}
+
+	type Company struct {
+		Name  string `json:"name" validate:"required"`
+		Other string `json:"Ref" validate:"required"`
+		Tel   string `json:"Phone" validate:"required"`
+	}
+
+	type Customer struct {
+		Contact
+		*Company
+		ID string `json:"id" validate:"required"`
+	}
+
+	// the conflicting names are resolved the same as by encoding/json: shallower fields hide deeper ones, tagged
+	// fields hide untagged ones at the same depth and conflicting names without a dominant field are dropped
+	b, err := json.Marshal(Customer{Company: &Company{}})
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"email":"","Ref":"","Phone":"","id":""}`)
+
+	validate := New(WithJSONFieldNames())
+
+	err = validate.Struct(Customer{Company: &Company{}})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 4)
+	AssertError(t, errs, "Customer.email", "Customer.Contact.Email", "email", "Email", "required")
+	AssertError(t, errs, "Customer.Ref", "Customer.Company.Other", "Ref", "Other", "required")
+	AssertError(t, errs, "Customer.Phone", "Customer.Company.Tel", "Phone", "Tel", "required")
+	AssertError(t, errs, "Customer.id", "Customer.ID", "id", "ID", "required")
+
+	// the embedded structs are unaffected when validated by themselves
+	err = validate.Struct(Contact{})
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 5)
+
+	// the hidden fields aren't expected in the data either
+	err = validate.ValidateMapAs(context.Background(), map[string]interface{}{"email": "a@b.co", "Ref": "r", "Phone": "1", "id": "1"}, Customer{})
+	Equal(t, err, nil)
+}
+
+func TestValidateMapErr(t *testing.T) {
+	var data map[string]interface{}
+
+	err := json.Unmarshal([]byte(`{
+		"name": "",
+		"email": "nope",
+		"tags": ["a", ""],
+		"address": {"city": ""},
+		"items": [{"name": "a", "qty": 1}, {"name": "", "qty": 0}, "oops"],
+		"owner": "bob",
+		"extra": true
+	}`), &data)
+	Equal(t, err, nil)
+
+	rules := map[string]interface{}{
+		"name":    "required",
+		"email":   "omitempty,email",
+		"tags":    "dive,min=1",
+		"address": map[string]interface{}{"city": "required"},
+		"items":   map[string]interface{}{"name": "required", "qty": "gt=0"},
+		"owner":   map[string]interface{}{"id": "required"},
+		"meta":    map[string]interface{}{"version": "required"},
+		"skipped": "-",
+	}
+
+	validate := New()
+
+	err = validate.ValidateMapErr(data, rules)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 8)
+	AssertError(t, errs, "address.city", "address.city", "city", "city", "required")
+	AssertError(t, errs, "email", "email", "email", "email", "email")
+	AssertError(t, errs, "items[1].name", "items[1].name", "name", "name", "required")
+	AssertError(t, errs, "items[1].qty", "items[1].qty", "qty", "qty", "gt")
+	AssertError(t, errs, "items[2]", "items[2]", "items[2]", "items[2]", "object")
+	AssertError(t, errs, "name", "name", "name", "name", "required")
+	AssertError(t, errs, "owner", "owner", "owner", "owner", "object")
+	AssertError(t, errs, "tags[1]", "tags[1]", "tags[1]", "tags[1]", "min")
+	Equal(t, errs[4].Value(), "oops")
+	Equal(t, errors.Is(err, ErrObject), true)
+
+	// errors are returned in the same order every time
+	for i := 0; i < 10; i++ {
+		Equal(t, validate.ValidateMapErr(data, rules).Error(), err.Error())
+	}
+
+	validate = New(WithStrictMaps())
+
+	err = validate.ValidateMapErrCtx(context.Background(), data, rules)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 10)
+	AssertError(t, errs, "meta", "meta", "meta", "meta", "required")
+	AssertError(t, errs, "extra", "extra", "extra", "extra", "unknown_key")
+	Equal(t, errors.Is(err, ErrUnknownKey), true)
+
+	err = validate.ValidateMapErr(
+		map[string]interface{}{"users": []map[string]interface{}{{"name": "a"}, {"name": "b", "age": 1}}},
+		map[string]interface{}{"users": map[string]interface{}{"name": "required"}},
+	)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "users[1].age", "users[1].age", "age", "age", "unknown_key")
+	Equal(t, errs[0].Value(), 1)
+
+	Equal(t, validate.ValidateMapErr(map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "required"}), nil)
+
+	// rules which are neither tags nor maps of rules would otherwise silently never be validated
+	PanicMatches(t, func() {
+		_ = validate.ValidateMapErr(data, map[string]interface{}{"tags": []string{"dive", "min=1"}})
+	}, "Invalid rule for key 'tags', must be a string of tags or a map[string]interface{} of rules, got '[]string'")
+	PanicMatches(t, func() {
+		_ = validate.ValidateMapErr(data, map[string]interface{}{"address": map[string]interface{}{"city": 1}})
+	}, "Invalid rule for key 'address.city', must be a string of tags or a map[string]interface{} of rules, got 'int'")
+}
+
+func TestValidateMapAs(t *testing.T) {
+	type Address struct {
+		City string `json:"city" validate:"required"`
+		Zip  string `json:"zip" validate:"omitempty,len=5"`
+	}
+
+	type Item struct {
+		SKU string `json:"sku" validate:"required"`
+		Qty uint8  `json:"qty" validate:"gt=0"`
+	}
+
+	type Order struct {
+		ID        int               `json:"id" validate:"required"`
+		Email     string            `json:"email" validate:"required,email"`
+		Address   *Address          `json:"address" validate:"required"`
+		Items     []Item            `json:"items" validate:"required,dive"`
+		Labels    map[int]string    `json:"labels" validate:"dive,required"`
+		Start     time.Time         `json:"start"`
+		End       time.Time         `json:"end" validate:"gtfield=Start"`
+		Meta      map[string]string `json:"meta"`
+		Extra     interface{}       `json:"extra"`
+		Confirmed bool              `json:"confirmed" validate:"eq=true"`
+	}
+
+	validate := New(WithJSONFieldNames())
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		o := sl.Current().Interface().(Order)
+		if len(o.Items) > 2 {
+			sl.ReportError(o.Items, "items", "Items", "max_items", "2")
+		}
+	}, Order{})
+
+	var data map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"id": 10,
+		"email": "a@b.co",
+		"address": {"city": "Paris", "zip": "75001"},
+		"items": [{"sku": "a", "qty": 1}],
+		"labels": {"1": "one"},
+		"start": "2024-01-01T00:00:00Z",
+		"end": "2024-01-02T00:00:00Z",
+		"meta": {"k": "v"},
+		"extra": [1, 2],
+		"confirmed": true,
+		"unknown": 1
+	}`), &data)
+	Equal(t, err, nil)
+
+	ctx := context.Background()
+	Equal(t, validate.ValidateMapAs(ctx, data, Order{}), nil)
+	Equal(t, validate.ValidateMapAs(ctx, data, (*Order)(nil)), nil)
+
+	err = json.Unmarshal([]byte(`{
+		"id": 1.5,
+		"email": "nope",
+		"address": {"city": ""},
+		"items": [{"sku": "a", "qty": 300}, {"sku": "", "qty": 1}, {"sku": "c", "qty": 1}],
+		"labels": {"x": "one", "2": ""},
+		"start": "2024-01-02T00:00:00Z",
+		"end": "not a time",
+		"confirmed": "yes"
+	}`), &data)
+	Equal(t, err, nil)
+
+	err = validate.ValidateMapAs(ctx, data, Order{})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 10)
+	AssertError(t, errs, "Order.id", "Order.ID", "id", "ID", "type")
+	AssertError(t, errs, "Order.items[0].qty", "Order.Items[0].Qty", "qty", "Qty", "type")
+	AssertError(t, errs, "Order.labels[x]", "Order.Labels[x]", "labels[x]", "Labels[x]", "type")
+	AssertError(t, errs, "Order.end", "Order.End", "end", "End", "type")
+	AssertError(t, errs, "Order.confirmed", "Order.Confirmed", "confirmed", "Confirmed", "type")
+	AssertError(t, errs, "Order.email", "Order.Email", "email", "Email", "email")
+	AssertError(t, errs, "Order.address.city", "Order.Address.City", "city", "City", "required")
+	AssertError(t, errs, "Order.items[1].sku", "Order.Items[1].SKU", "sku", "SKU", "required")
+	AssertError(t, errs, "Order.labels[2]", "Order.Labels[2]", "labels[2]", "Labels[2]", "required")
+	AssertError(t, errs, "Order.items", "Order.Items", "items", "Items", "max_items")
+	Equal(t, errs[0].Param(), "int")
+	Equal(t, errs[0].Value(), 1.5)
+	Equal(t, errors.Is(err, ErrType), true)
+
+	// struct values are reported using the 'type' tag when they aren't objects
+	err = validate.ValidateMapAs(ContextWithRootName(ctx, ""), map[string]interface{}{"address": "Paris"}, Order{})
+	NotEqual(t, err, nil)
+	AssertError(t, err, "address", "Order.Address", "address", "Address", "type")
+
+	validate = New(WithJSONFieldNames(), WithStrictMaps())
+
+	err = validate.ValidateMapAs(ctx, map[string]interface{}{
+		"id": 1, "email": "a@b.co", "confirmed": true, "unknown": 1,
+		"address": map[string]interface{}{"city": "Paris", "country": "FR"},
+		"items":   []interface{}{map[string]interface{}{"sku": "a", "qty": 1}},
+		"labels":  map[string]interface{}{},
+		"start":   "2024-01-01T00:00:00Z",
+		"end":     "2024-01-02T00:00:00Z",
+	}, Order{})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Order.address.country", "Order.Address.country", "country", "country", "unknown_key")
+	AssertError(t, errs, "Order.unknown", "Order.unknown", "unknown", "unknown", "unknown_key")
+
+	err = validate.ValidateMapAs(ctx, nil, "string")
+	NotEqual(t, err, nil)
+	Equal(t, errors.Is(err, ErrInvalidValidation), true)
+}
+
+func TestValidateMapAsMissingKeys(t *testing.T) {
+	type Item struct {
+		SKU string `json:"sku" validate:"required"`
+	}
+
+	type Settings struct {
+		Count  int      `json:"count" validate:"required"`
+		Limit  int      `json:"limit" validate:"omitempty,min=10"`
+		Name   string   `json:"name" validate:"required"`
+		Ratio  float64  `json:"ratio" validate:"gte=0"`
+		Quiet  bool     `json:"quiet"`
+		Items  []Item   `json:"items" validate:"omitempty,dive"`
+		Parent *Item    `json:"parent" validate:"omitempty"`
+		Tags   []string `json:"tags" validate:"dive,min=1"`
+		Codes  []string `json:"codes" validate:"required,dive,len=2"`
+		Code   string   `json:"code" validate:"required_if=Quiet true,len=2"`
+	}
+
+	validate := New(WithJSONFieldNames())
+	ctx := context.Background()
+
+	// the zero values are present so only fail the validations of their values
+	err := validate.ValidateMapAs(ctx, map[string]interface{}{
+		"count": 0, "limit": 0, "name": "", "ratio": 0, "quiet": false, "codes": []interface{}{"ab"},
+	}, Settings{})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Settings.count", "Settings.Count", "count", "Count", "required")
+	AssertError(t, errs, "Settings.name", "Settings.Name", "name", "Name", "required")
+
+	// while the missing keys, or nil values, fail as missing values, only the 'required' validations applying to them
+	err = validate.ValidateMapAs(ctx, map[string]interface{}{
+		"count": 1, "name": nil,
+		"items":  []interface{}{map[string]interface{}{}, map[string]interface{}{"sku": ""}},
+		"parent": map[string]interface{}{},
+	}, Settings{})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertError(t, errs, "Settings.name", "Settings.Name", "name", "Name", "required")
+	AssertError(t, errs, "Settings.items[0].sku", "Settings.Items[0].SKU", "sku", "SKU", "required")
+	AssertError(t, errs, "Settings.items[1].sku", "Settings.Items[1].SKU", "sku", "SKU", "required")
+	AssertError(t, errs, "Settings.parent.sku", "Settings.Parent.SKU", "sku", "SKU", "required")
+	AssertError(t, errs, "Settings.codes", "Settings.Codes", "codes", "Codes", "required")
+	Equal(t, errs[0].Kind(), reflect.Invalid)
+	Equal(t, errs[0].Value(), nil)
+
+	// the conditional 'required_*' validations are evaluated against the parent
+	err = validate.ValidateMapAs(ctx, map[string]interface{}{"count": 1, "name": "a", "codes": []interface{}{"ab"}, "quiet": true}, Settings{})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Settings.code", "Settings.Code", "code", "Code", "required_if")
+
+	// the other validations, including those of the elements after dive, are skipped
+	Equal(t, validate.ValidateMapAs(ctx, map[string]interface{}{"count": 1, "name": "a", "codes": []interface{}{"ab"}}, Settings{}), nil)
+	Equal(t, validate.ValidateMapAs(ctx, map[string]interface{}{"count": 1, "name": "a", "codes": []interface{}{"ab"}, "ratio": 0.5}, Settings{}), nil)
+
+	// present values are still validated
+	err = validate.ValidateMapAs(ctx, map[string]interface{}{"count": 1, "name": "a", "codes": []interface{}{"abc"}, "ratio": -1, "tags": []interface{}{""}}, Settings{})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "Settings.ratio", "Settings.Ratio", "ratio", "Ratio", "gte")
+	AssertError(t, errs, "Settings.tags[0]", "Settings.Tags[0]", "tags[0]", "Tags[0]", "min")
+	AssertError(t, errs, "Settings.codes[0]", "Settings.Codes[0]", "codes[0]", "Codes[0]", "len")
+}
+
+func TestStructValidationMapRulePaths(t *testing.T) {
+	type Address struct {
+		City    string
+		Country string
+	}
+
+	type Item struct {
+		SKU string
+		Qty int
+	}
+
+	type Order struct {
+		Address  Address
+		Billing  *Address
+		Items    []Item
+		Meta     map[string]string
+		Tags     []string `validate:"min=1,dive,alpha"`
+		Matrix   [][]Item
+		Contacts []Address
+	}
+
+	validate := New()
+	validate.RegisterStructValidationMapRules(map[string]string{
+		"Address.City":     "required",
+		"Billing":          "required",
+		"Billing.Country":  "len=2",
+		"Items":            "min=1",
+		"Items[*].SKU":     "required",
+		"Meta[*]":          "max=3",
+		"Tags[*]":          "len=2",
+		"Matrix[*][*].Qty": "gt=0",
+		"Contacts[*]":      "required",
+		"Contacts[*].City": "required",
+	}, Order{})
+
+	tst := Order{
+		Billing:  &Address{Country: "FRA"},
+		Items:    []Item{{SKU: "a"}, {}},
+		Meta:     map[string]string{"k": "long"},
+		Tags:     []string{"abc"},
+		Matrix:   [][]Item{{{Qty: 1}, {Qty: 0}}},
+		Contacts: []Address{{City: "Paris"}, {}},
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 7)
+	AssertError(t, errs, "Order.Address.City", "Order.Address.City", "City", "City", "required")
+	AssertError(t, errs, "Order.Billing.Country", "Order.Billing.Country", "Country", "Country", "len")
+	AssertError(t, errs, "Order.Items[1].SKU", "Order.Items[1].SKU", "SKU", "SKU", "required")
+	AssertError(t, errs, "Order.Meta[k]", "Order.Meta[k]", "Meta[k]", "Meta[k]", "max")
+	AssertError(t, errs, "Order.Tags[0]", "Order.Tags[0]", "Tags[0]", "Tags[0]", "len")
+	AssertError(t, errs, "Order.Matrix[0][1].Qty", "Order.Matrix[0][1].Qty", "Qty", "Qty", "gt")
+	AssertError(t, errs, "Order.Contacts[1].City", "Order.Contacts[1].City", "City", "City", "required")
+
+	// the rules only apply when reached through the path
+	Equal(t, validate.Struct(Address{}), nil)
+	Equal(t, validate.Var([]Item{{}}, "dive"), nil)
+
+	err = validate.Struct(Order{Items: []Item{{SKU: "a"}}, Tags: []string{}})
+	NotEqual(t, err, nil)
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "Order.Address.City", "Order.Address.City", "City", "City", "required")
+	AssertError(t, errs, "Order.Billing", "Order.Billing", "Billing", "Billing", "required")
+	AssertError(t, errs, "Order.Tags", "Order.Tags", "Tags", "Tags", "min")
+
+	PanicMatches(t, func() {
+		validate.RegisterStructValidationMapRules(map[string]string{"Address.Street": "required"}, Order{})
+	}, "Invalid rule path 'Address.Street' on type 'validator.Order': field 'Street' not found on 'validator.Address'")
+
+	PanicMatches(t, func() {
+		validate.RegisterStructValidationMapRules(map[string]string{"Address[*]": "required"}, Order{})
+	}, "Invalid rule path 'Address[*]' on type 'validator.Order': 'validator.Address' is not a slice, array or map")
+
+	PanicMatches(t, func() {
+		validate.RegisterStructValidationMapRules(map[string]string{"Items[0].SKU": "required"}, Order{})
+	}, "Invalid rule path 'Items[0].SKU' on type 'validator.Order': only '[*]' is supported for elements")
+
+	PanicMatches(t, func() {
+		validate.RegisterStructValidationMapRules(map[string]string{"Meta[*].Key": "required"}, Order{})
+	}, "Invalid rule path 'Meta[*].Key' on type 'validator.Order': 'string' is not a struct")
+}
+
+func TestRegisterTypeRules(t *testing.T) {
+	type Email string
+	type TenantID [4]byte
+	type Code string
+
+	type Test struct {
+		Email     Email            `validate:"max=10"`
+		Optional  Email            `validate:"omitempty"`
+		Pointer   *Email           `validate:"required"`
+		Emails    []Email          `validate:"max=3"`
+		Dived     []Email          `validate:"dive,min=5"`
+		ByName    map[string]Email `validate:"dive,keys,min=2,endkeys,max=20"`
+		Nested    [][]Email
+		Tenant    TenantID
+		Unchecked Email           `validate:"notyperules"`
+		Skipped   Email           `validate:"-"`
+		Codes     map[Code]string // map keys don't get the type rules
+	}
+
+	validate := New()
+	validate.RegisterTypeRules("email", Email(""))
+	validate.RegisterTypeRules("required", TenantID{})
+	validate.RegisterTypeRules("len=3", Code(""))
+
+	bad := Email("nope")
+
+	tst := Test{
+		Email:     "not-an-email",
+		Pointer:   &bad,
+		Emails:    []Email{"a@b.co", "x"},
+		Dived:     []Email{"ab"},
+		ByName:    map[string]Email{"a": "ab"},
+		Nested:    [][]Email{{"a@b.co", "y"}},
+		Unchecked: "nope",
+		Skipped:   "nope",
+		Codes:     map[Code]string{"abcd": "x"},
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 8)
+	AssertError(t, errs, "Test.Email", "Test.Email", "Email", "Email", "email")
+	AssertError(t, errs, "Test.Pointer", "Test.Pointer", "Pointer", "Pointer", "email")
+	AssertError(t, errs, "Test.Emails[1]", "Test.Emails[1]", "Emails[1]", "Emails[1]", "email")
+	AssertError(t, errs, "Test.Dived[0]", "Test.Dived[0]", "Dived[0]", "Dived[0]", "email")
+	AssertError(t, errs, "Test.ByName[a]", "Test.ByName[a]", "ByName[a]", "ByName[a]", "min")
+	AssertDeepError(t, errs, "Test.ByName[a]", "Test.ByName[a]", "ByName[a]", "ByName[a]", "email", "email")
+	AssertError(t, errs, "Test.Nested[0][1]", "Test.Nested[0][1]", "Nested[0][1]", "Nested[0][1]", "email")
+	AssertError(t, errs, "Test.Tenant", "Test.Tenant", "Tenant", "Tenant", "required")
+
+	// the type rules are added after the leading omitempty or required tags
+	Equal(t, insertTypeRules("omitempty,required,max=5", "email"), "omitempty,required,email,max=5")
+	Equal(t, insertTypeRules("omitempty", "email"), "omitempty,email")
+	Equal(t, insertTypeRules("", "email"), "email")
+
+	Equal(t, validate.Var(Email("a@b.co"), ""), nil)
+	AssertError(t, validate.Var(Email("nope"), ""), "", "", "", "", "email")
+	AssertError(t, validate.Var([]Email{"nope"}, "min=1"), "[0]", "[0]", "[0]", "[0]", "email")
+	Equal(t, validate.Var(Email("nope"), "notyperules"), nil)
+	Equal(t, validate.Var(Email("nope"), "-"), nil)
+
+	PanicMatches(t, func() { _ = validate.RegisterValidation(noTypeRulesTag, hasValue) }, fmt.Sprintf(restrictedTagErr, noTypeRulesTag))
+}
+
+func TestRulesBuilder(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string
+	}
+
+	type Embedded struct {
+		Tag string
+	}
+
+	type User struct {
+		Embedded
+		Name    string `validate:"max=3"`
+		Age     int
+		Address Address
+		Other   *Address
+	}
+
+	validate := New()
+	Rules[User](validate).
+		Field(func(u *User) interface{} { return &u.Name }, "required,max=8").
+		Field(func(u *User) interface{} { return &u.Age }, "gte=18").
+		Field(func(u *User) interface{} { return &u.Address.City }, "required").
+		Field(func(u *User) interface{} { return &u.Tag }, "required").
+		Struct(func(sl StructLevel, u User) {
+			if u.Name == u.Address.Zip {
+				sl.ReportError(u.Name, "Name", "Name", "nezip", "")
+			}
+		})
+
+	err := validate.Struct(User{Name: "joeybloggs", Age: 17, Address: Address{Zip: "joeybloggs"}})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertError(t, errs, "User.Embedded.Tag", "User.Embedded.Tag", "Tag", "Tag", "required")
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "max")
+	AssertError(t, errs, "User.Age", "User.Age", "Age", "Age", "gte")
+	AssertError(t, errs, "User.Address.City", "User.Address.City", "City", "City", "required")
+	AssertDeepError(t, errs, "User.Name", "User.Name", "Name", "Name", "nezip", "nezip")
+
+	Equal(t, validate.Struct(User{Embedded: Embedded{Tag: "a"}, Name: "joey", Age: 18, Address: Address{City: "here"}}), nil)
+
+	// the rules only apply to the nested struct when reached through the field
+	Equal(t, validate.Struct(Address{}), nil)
+
+	PanicMatches(t, func() { Rules[string](validate) }, "validator: Rules requires a struct type, got 'string'")
+	PanicMatches(t, func() {
+		Rules[User](validate).Field(func(u *User) interface{} { return u.Name }, "required")
+	}, "validator: field of 'validator.User' must be selected using a pointer eg. &t.Field")
+	PanicMatches(t, func() {
+		Rules[User](validate).Field(func(u *User) interface{} { return &Address{} }, "required")
+	}, "validator: selected field is not a field of 'validator.User'")
+	PanicMatches(t, func() {
+		Rules[User](validate).Field(func(u *User) interface{} { return u.Other }, "required")
+	}, "validator: field of 'validator.User' must be selected using a pointer eg. &t.Field")
+}
+
+func TestRegisterValidationFor(t *testing.T) {
+	type Cents int64
+
+	type Test struct {
+		Price    Cents            `validate:"maxcents=100"`
+		Ptr      *Cents           `validate:"omitempty,maxcents=100"`
+		Prices   []Cents          `validate:"dive,maxcents=100"`
+		ByName   map[string]Cents `validate:"dive,keys,min=1,endkeys,maxcents=100"`
+		Stringer fmt.Stringer     `validate:"omitempty,short"`
+		Any      interface{}      `validate:"omitempty,maxcents=100"`
+	}
+
+	validate := New()
+
+	err := RegisterValidationFor(validate, "maxcents", func(ctx context.Context, c Cents, param string) bool {
+		return int64(c) <= asInt(param)
+	})
+	Equal(t, err, nil)
+
+	err = RegisterValidationFor(validate, "short", func(ctx context.Context, s fmt.Stringer, param string) bool {
+		return len(s.String()) < 5
+	})
+	Equal(t, err, nil)
+
+	var structCalls int
+
+	RegisterStructValidationFor(validate, func(ctx context.Context, sl StructLevel, tst Test) {
+		structCalls++
+		if tst.Price == 0 {
+			sl.ReportError(tst.Price, "Price", "Price", "nonzero", "")
+		}
+	})
+
+	big := Cents(101)
+
+	tst := &Test{
+		Price:    101,
+		Ptr:      &big,
+		Prices:   []Cents{1, 101},
+		ByName:   map[string]Cents{"a": 101},
+		Stringer: time.Hour,
+		Any:      "not cents",
+	}
+
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+	Equal(t, structCalls, 1)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 6)
+	AssertError(t, errs, "Test.Price", "Test.Price", "Price", "Price", "maxcents")
+	AssertError(t, errs, "Test.Ptr", "Test.Ptr", "Ptr", "Ptr", "maxcents")
+	AssertError(t, errs, "Test.Prices[1]", "Test.Prices[1]", "Prices[1]", "Prices[1]", "maxcents")
+	AssertError(t, errs, "Test.ByName[a]", "Test.ByName[a]", "ByName[a]", "ByName[a]", "maxcents")
+	AssertError(t, errs, "Test.Stringer", "Test.Stringer", "Stringer", "Stringer", "short")
+	AssertError(t, errs, "Test.Any", "Test.Any", "Any", "Any", "maxcents")
+
+	// non addressable values are supported as well
+	err = validate.Struct(Test{})
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "Test.Price", "Test.Price", "Price", "Price", "nonzero")
+
+	Equal(t, validate.Struct(Test{Price: 1, Stringer: time.Second, Any: Cents(1)}), nil)
+	Equal(t, structCalls, 3)
+
+	Equal(t, validate.Var(Cents(100), "maxcents=100"), nil)
+	AssertError(t, validate.Var(100, "maxcents=100"), "", "", "", "", "maxcents")
+
+	type Mismatch struct {
+		Price int64 `validate:"maxcents=100"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(Mismatch{}) }, "Validation 'maxcents' on field 'Price' requires type 'validator.Cents', got 'int64'")
+
+	type MismatchKeys struct {
+		ByCents map[Cents]string `validate:"dive,keys,maxcents=100,endkeys,maxcents=100"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(MismatchKeys{}) }, "Validation 'maxcents' on field 'ByCents' requires type 'validator.Cents', got 'string'")
+
+	// the values of unexported fields aren't exposed to the validation, which fails instead
+	type Private struct {
+		price Cents  `validate:"maxcents=100"`
+		ptr   *Cents `validate:"centsptr"`
+	}
+
+	var privateCalls int
+
+	private := New(WithPrivateFieldValidation())
+
+	err = RegisterValidationFor(private, "maxcents", func(ctx context.Context, c Cents, param string) bool {
+		privateCalls++
+		return true
+	})
+	Equal(t, err, nil)
+
+	err = RegisterValidationFor(private, "centsptr", func(ctx context.Context, c *Cents, param string) bool {
+		privateCalls++
+		return true
+	})
+	Equal(t, err, nil)
+
+	one := Cents(1)
+
+	for _, val := range []interface{}{&Private{price: 1, ptr: &one}, Private{price: 1, ptr: &one}} {
+		err = private.Struct(val)
+		NotEqual(t, err, nil)
+
+		errs = err.(ValidationErrors)
+		Equal(t, len(errs), 2)
+		AssertError(t, errs, "Private.price", "Private.price", "price", "price", "maxcents")
+		AssertError(t, errs, "Private.ptr", "Private.ptr", "ptr", "ptr", "centsptr")
+	}
+
+	Equal(t, privateCalls, 0)
+
+	// registering the tag again without a type removes the check
+	Equal(t, validate.RegisterValidation("maxcents", hasValue), nil)
+
+	type Untyped struct {
+		Price int64 `validate:"maxcents"`
+	}
+
+	Equal(t, validate.Struct(Untyped{Price: 1}), nil)
+
+	PanicMatches(t, func() {
+		RegisterStructValidationFor(validate, func(ctx context.Context, sl StructLevel, s string) {})
+	}, "validator: RegisterStructValidationFor requires a struct type, got 'string'")
+}
+
+func TestRegisterValidationForPointer(t *testing.T) {
+	type Test struct {
+		Ptr    *big.Int   `validate:"omitempty,positive"`
+		Value  big.Int    `validate:"positive"`
+		Values []*big.Int `validate:"dive,positive"`
+	}
+
+	validate := New()
+
+	err := RegisterValidationFor(validate, "positive", func(ctx context.Context, i *big.Int, param string) bool {
+		return i.Sign() > 0
+	})
+	Equal(t, err, nil)
+
+	tst := &Test{
+		Ptr:    big.NewInt(-1),
+		Value:  *big.NewInt(1),
+		Values: []*big.Int{big.NewInt(1), big.NewInt(0)},
+	}
+
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Test.Ptr", "Test.Ptr", "Ptr", "Ptr", "positive")
+	AssertError(t, errs, "Test.Values[1]", "Test.Values[1]", "Values[1]", "Values[1]", "positive")
+
+	// non addressable values are passed a pointer to a copy
+	err = validate.Struct(Test{})
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "Test.Value", "Test.Value", "Value", "Value", "positive")
+
+	Equal(t, validate.Struct(Test{Value: *big.NewInt(2)}), nil)
+
+	type Mismatch struct {
+		Value int64 `validate:"positive"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(Mismatch{}) }, "Validation 'positive' on field 'Value' requires type '*big.Int', got 'int64'")
+}
+
+type optionalValue[T any] struct {
+	val T
+	ok  bool
+}
+
+func (o optionalValue[T]) Get() (interface{}, bool) {
+	return o.val, o.ok
+}
+
+type textID struct {
+	id string
+}
+
+func (t *textID) MarshalText() ([]byte, error) {
+	return []byte(t.id), nil
+}
+
+type selfValuer string
+
+func (s selfValuer) Value() (driver.Value, error) {
+	return s, nil
+}
+
+func TestRegisterCustomTypeFuncByInterface(t *testing.T) {
+	type Test struct {
+		Name    optionalValue[string] `validate:"required,max=3"`
+		Age     optionalValue[int]    `validate:"omitempty,gte=18"`
+		Missing optionalValue[int]    `validate:"required"`
+		ID      textID                `validate:"len=4"`
+		IDPtr   *textID               `validate:"len=4"`
+		Self    selfValuer            `validate:"max=2"`
+		Valuer  valuer                `validate:"required"` // the function registered against the type takes precedence
+	}
+
+	validate := New()
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return "overridden"
+	}, valuer{})
+
+	validate.RegisterCustomTypeFuncByInterface(func(field reflect.Value) interface{} {
+		if val, ok := field.Interface().(interface{ Get() (interface{}, bool) }).Get(); ok {
+			return val
+		}
+		return nil
+	}, (*interface{ Get() (interface{}, bool) })(nil))
+
+	validate.RegisterCustomTypeFuncByInterface(func(field reflect.Value) interface{} {
+		b, _ := field.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b)
+	}, (*encoding.TextMarshaler)(nil))
+
+	validate.RegisterCustomTypeFuncByInterface(ValidateValuerType, (*driver.Valuer)(nil))
+
+	tst := Test{
+		Name:  optionalValue[string]{val: "joeybloggs", ok: true},
+		Age:   optionalValue[int]{val: 12, ok: true},
+		ID:    textID{id: "abc"},
+		IDPtr: &textID{id: "abcde"},
+		Self:  "abc",
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 6)
+	AssertError(t, errs, "Test.Name", "Test.Name", "Name", "Name", "max")
+	AssertError(t, errs, "Test.Age", "Test.Age", "Age", "Age", "gte")
+	AssertError(t, errs, "Test.Missing", "Test.Missing", "Missing", "Missing", "required")
+	AssertError(t, errs, "Test.ID", "Test.ID", "ID", "ID", "len")
+	AssertError(t, errs, "Test.IDPtr", "Test.IDPtr", "IDPtr", "IDPtr", "len")
+	AssertError(t, errs, "Test.Self", "Test.Self", "Self", "Self", "max")
+
+	tst = Test{
+		Name:    optionalValue[string]{val: "joe", ok: true},
+		Missing: optionalValue[int]{val: 1, ok: true},
+		ID:      textID{id: "abcd"},
+		IDPtr:   &textID{id: "abcd"},
+		Self:    "ab",
+	}
+
+	Equal(t, validate.Struct(tst), nil)
+	Equal(t, validate.Struct(&tst), nil)
+
+	Equal(t, validate.Var(optionalValue[int]{val: 20, ok: true}, "gte=18"), nil)
+	AssertError(t, validate.Var(optionalValue[int]{}, "required"), "", "", "", "", "required")
+
+	PanicMatches(t, func() { validate.RegisterCustomTypeFuncByInterface(ValidateValuerType, valuer{}) },
+		"validator: interfaces must be passed as nil pointers to them eg. (*driver.Valuer)(nil), got 'validator.valuer'")
+}
+
+func TestSQLNullTypes(t *testing.T) {
+	type Test struct {
+		Name     sql.NullString  `validate:"required,max=3"`
+		Optional sql.NullString  `validate:"omitempty,max=3"`
+		Count    sql.NullInt64   `validate:"gte=1"`
+		Small    sql.NullInt16   `validate:"omitempty,lte=10"`
+		Score    sql.NullFloat64 `validate:"required"`
+		Created  sql.NullTime    `validate:"required"`
+		Active   *sql.NullBool   `validate:"omitempty,required"`
+	}
+
+	validate := New(WithSQLNullTypes())
+
+	tst := Test{
+		Name:  sql.NullString{String: "joeybloggs", Valid: true},
+		Count: sql.NullInt64{Int64: 0, Valid: true},
+		Small: sql.NullInt16{Int16: 11, Valid: true},
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertError(t, errs, "Test.Name", "Test.Name", "Name", "Name", "max")
+	AssertError(t, errs, "Test.Count", "Test.Count", "Count", "Count", "gte")
+	AssertError(t, errs, "Test.Small", "Test.Small", "Small", "Small", "lte")
+	AssertError(t, errs, "Test.Score", "Test.Score", "Score", "Score", "required")
+	AssertError(t, errs, "Test.Created", "Test.Created", "Created", "Created", "required")
+
+	tst = Test{
+		Name:    sql.NullString{String: "joe", Valid: true},
+		Count:   sql.NullInt64{Int64: 1, Valid: true},
+		Score:   sql.NullFloat64{Float64: 0.5, Valid: true},
+		Created: sql.NullTime{Time: time.Now(), Valid: true},
+		Active:  &sql.NullBool{Bool: true, Valid: true},
+	}
+
+	Equal(t, validate.Struct(tst), nil)
+}
+
+type zeroMoney struct {
+	amount *int64
+}
+
+func (m zeroMoney) IsZero() bool {
+	return m.amount == nil || *m.amount == 0
+}
+
+type zeroCounter struct {
+	n       int
+	touched bool
+}
+
+func (c *zeroCounter) IsZero() bool {
+	return c.n == 0
+}
+
+func TestIsZeroMethod(t *testing.T) {
+	type Test struct {
+		Money    zeroMoney   `validate:"required"`
+		Counter  zeroCounter `validate:"required"`
+		Time     time.Time   `validate:"required"`
+		Default  zeroMoney   `validate:"isdefault"`
+		Optional zeroMoney   `validate:"omitempty,fail"`
+		With     zeroMoney   `validate:"required_with=Time"`
+		Excluded zeroMoney   `validate:"excluded_with=Money"`
+	}
+
+	validate := New(WithRequiredStructEnabled())
+	_ = validate.RegisterValidation("fail", func(fl FieldLevel) bool { return false })
+
+	zero := int64(0)
+	one := int64(1)
+
+	tst := Test{
+		Money:    zeroMoney{amount: &zero},
+		Counter:  zeroCounter{touched: true},
+		Time:     time.Time{}.In(time.FixedZone("CET", 3600)),
+		Default:  zeroMoney{amount: &zero},
+		Optional: zeroMoney{amount: &zero},
+		With:     zeroMoney{amount: &zero},
+		Excluded: zeroMoney{amount: &zero},
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "Test.Money", "Test.Money", "Money", "Money", "required")
+	AssertError(t, errs, "Test.Counter", "Test.Counter", "Counter", "Counter", "required")
+	AssertError(t, errs, "Test.Time", "Test.Time", "Time", "Time", "required")
+
+	tst.Money = zeroMoney{amount: &one}
+	tst.Counter = zeroCounter{n: 1}
+	tst.Time = time.Now()
+	tst.Excluded = zeroMoney{amount: &one}
+
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Test.With", "Test.With", "With", "With", "required_with")
+	AssertError(t, errs, "Test.Excluded", "Test.Excluded", "Excluded", "Excluded", "excluded_with")
+
+	Equal(t, validate.Var(time.Time{}.In(time.FixedZone("CET", 3600)), "isdefault"), nil)
+	AssertError(t, validate.Var(time.Time{}.In(time.FixedZone("CET", 3600)), "required"), "", "", "", "", "required")
+}
+
+func TestOmitZero(t *testing.T) {
+	type Test struct {
+		String   string       `validate:"omitzero,min=3"`
+		Ptr      *string      `validate:"omitzero,min=3"`
+		EmptyPtr *int         `validate:"omitempty,min=3"`
+		ZeroPtr  *int         `validate:"omitzero,min=3"`
+		Slice    []string     `validate:"omitzero,min=1"`
+		Money    zeroMoney    `validate:"omitzero,required"`
+		Counter  *zeroCounter `validate:"omitzero,required"`
+		Time     time.Time    `validate:"omitzero,required"`
+	}
+
+	validate := New(WithRequiredStructEnabled())
+
+	empty := ""
+	zero := 0
+
+	tst := Test{
+		Ptr:      &empty,
+		EmptyPtr: &zero,
+		ZeroPtr:  &zero,
+		Money:    zeroMoney{amount: new(int64)},
+		Counter:  &zeroCounter{touched: true},
+		Time:     time.Time{}.In(time.FixedZone("CET", 3600)),
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	// unlike omitempty, omitzero skips pointers to zero values
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Test.EmptyPtr", "Test.EmptyPtr", "EmptyPtr", "EmptyPtr", "min")
+
+	tst = Test{String: "ab", Slice: []string{}}
+
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Test.String", "Test.String", "String", "String", "min")
+	AssertError(t, errs, "Test.Slice", "Test.Slice", "Slice", "Slice", "min")
+
+	Equal(t, validate.Var(0, "omitzero,min=1"), nil)
+	AssertError(t, validate.Var(1, "omitzero,min=2"), "", "", "", "", "min")
+
+	PanicMatches(t, func() { _ = validate.RegisterValidation(omitzero, hasValue) }, fmt.Sprintf(restrictedTagErr, omitzero))
+}
+
+type lenSet struct {
+	items map[string]struct{}
+}
+
+func (s lenSet) Len() int {
+	return len(s.items)
+}
+
+func newLenSet(items ...string) lenSet {
+	s := lenSet{items: make(map[string]struct{})}
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+	return s
+}
+
+type cmpDecimal struct {
+	cents int64
+}
+
+func (d cmpDecimal) Compare(other cmpDecimal) int {
+	return compareInts(d.cents, other.cents)
+}
+
+func (cmpDecimal) ParseParam(s string) (cmpDecimal, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	return cmpDecimal{cents: int64(math.Round(f * 100))}, err
+}
+
+func TestLenAndCompareMethods(t *testing.T) {
+	type Test struct {
+		Tags     lenSet     `validate:"min=1,max=2"`
+		Exact    lenSet     `validate:"len=2"`
+		More     lenSet     `validate:"gtfield=Tags"`
+		Price    cmpDecimal `validate:"gte=0.5,lt=100"`
+		Discount cmpDecimal `validate:"ltfield=Price"`
+		Same     cmpDecimal `validate:"eqfield=Price"`
+		Big      *big.Int   `validate:"gt=100"`
+		BigMax   big.Int    `validate:"ltefield=Big"`
+		NotFive  cmpDecimal `validate:"ne=5"`
+	}
+
+	validate := New()
+
+	tst := &Test{
+		Tags:     newLenSet("a", "b", "c"),
+		Exact:    newLenSet("a"),
+		More:     newLenSet("a"),
+		Price:    cmpDecimal{cents: 49},
+		Discount: cmpDecimal{cents: 50},
+		Same:     cmpDecimal{cents: 50},
+		Big:      big.NewInt(100),
+		BigMax:   *big.NewInt(101),
+		NotFive:  cmpDecimal{cents: 500},
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 9)
+	AssertError(t, errs, "Test.Tags", "Test.Tags", "Tags", "Tags", "max")
+	AssertError(t, errs, "Test.Exact", "Test.Exact", "Exact", "Exact", "len")
+	AssertError(t, errs, "Test.More", "Test.More", "More", "More", "gtfield")
+	AssertError(t, errs, "Test.Price", "Test.Price", "Price", "Price", "gte")
+	AssertError(t, errs, "Test.Discount", "Test.Discount", "Discount", "Discount", "ltfield")
+	AssertError(t, errs, "Test.Same", "Test.Same", "Same", "Same", "eqfield")
+	AssertError(t, errs, "Test.Big", "Test.Big", "Big", "Big", "gt")
+	AssertError(t, errs, "Test.BigMax", "Test.BigMax", "BigMax", "BigMax", "ltefield")
+	AssertError(t, errs, "Test.NotFive", "Test.NotFive", "NotFive", "NotFive", "ne")
+
+	tst = &Test{
+		Tags:     newLenSet("a", "b"),
+		Exact:    newLenSet("a", "b"),
+		More:     newLenSet("a", "b", "c"),
+		Price:    cmpDecimal{cents: 50},
+		Discount: cmpDecimal{cents: 49},
+		Same:     cmpDecimal{cents: 50},
+		Big:      big.NewInt(101),
+		BigMax:   *big.NewInt(101),
+		NotFive:  cmpDecimal{cents: 499},
+	}
+
+	Equal(t, validate.Struct(tst), nil)
+
+	type Exact struct {
+		Price cmpDecimal `validate:"eq=1.5"`
+	}
+
+	Equal(t, validate.Struct(Exact{Price: cmpDecimal{cents: 150}}), nil)
+	AssertError(t, validate.Struct(Exact{Price: cmpDecimal{cents: 100}}), "Exact.Price", "Exact.Price", "Price", "Price", "eq")
+
+	type Bad struct {
+		Big *big.Int `validate:"gt=abc"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(Bad{Big: big.NewInt(1)}) }, "math/big: cannot unmarshal \"abc\" into a *big.Int")
+}
+
+func TestNetworkTypes(t *testing.T) {
+	type Test struct {
+		Addr      netip.Addr     `validate:"ip"`
+		Addr4     netip.Addr     `validate:"ipv4"`
+		Addr6     netip.Addr     `validate:"ipv6"`
+		Prefix    netip.Prefix   `validate:"cidrv4"`
+		AddrPort  netip.AddrPort `validate:"tcp4_addr"`
+		HostPort  netip.AddrPort `validate:"hostname_port"`
+		IP        net.IP         `validate:"ip"`
+		URL       *url.URL       `validate:"http_url"`
+		URI       url.URL        `validate:"uri"`
+		ZeroAddr  netip.Addr     `validate:"ip"`
+		EmptyIP   net.IP         `validate:"ip"`
+		OptAddr   *netip.Addr    `validate:"omitempty,ip"`
+		Addresses []netip.Addr   `validate:"dive,ipv6"`
+	}
+
+	validate := New()
+
+	u, _ := url.Parse("https://example.com/path")
+
+	tst := Test{
+		Addr:      netip.MustParseAddr("10.0.0.1"),
+		Addr4:     netip.MustParseAddr("10.0.0.1"),
+		Addr6:     netip.MustParseAddr("::1"),
+		Prefix:    netip.MustParsePrefix("10.0.0.0/8"),
+		AddrPort:  netip.MustParseAddrPort("127.0.0.1:80"),
+		HostPort:  netip.MustParseAddrPort("[::1]:80"),
+		IP:        net.ParseIP("192.168.0.1"),
+		URL:       u,
+		URI:       *u,
+		ZeroAddr:  netip.MustParseAddr("::"),
+		EmptyIP:   net.IPv4(1, 2, 3, 4),
+		Addresses: []netip.Addr{netip.MustParseAddr("fe80::1")},
+	}
+
+	Equal(t, validate.Struct(tst), nil)
+
+	tst = Test{
+		Addr4:     netip.MustParseAddr("::1"),
+		Addr6:     netip.MustParseAddr("10.0.0.1"),
+		Prefix:    netip.MustParsePrefix("10.0.0.1/8"),
+		AddrPort:  netip.MustParseAddrPort("[::1]:80"),
+		HostPort:  netip.AddrPortFrom(netip.MustParseAddr("::1"), 0),
+		URL:       &url.URL{Scheme: "ftp", Host: "example.com"},
+		Addresses: []netip.Addr{{}},
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 12)
+	AssertError(t, errs, "Test.Addr", "Test.Addr", "Addr", "Addr", "ip")
+	AssertError(t, errs, "Test.Addr4", "Test.Addr4", "Addr4", "Addr4", "ipv4")
+	AssertError(t, errs, "Test.Addr6", "Test.Addr6", "Addr6", "Addr6", "ipv6")
+	AssertError(t, errs, "Test.Prefix", "Test.Prefix", "Prefix", "Prefix", "cidrv4")
+	AssertError(t, errs, "Test.AddrPort", "Test.AddrPort", "AddrPort", "AddrPort", "tcp4_addr")
+	AssertError(t, errs, "Test.HostPort", "Test.HostPort", "HostPort", "HostPort", "hostname_port")
+	AssertError(t, errs, "Test.IP", "Test.IP", "IP", "IP", "ip")
+	AssertError(t, errs, "Test.URL", "Test.URL", "URL", "URL", "http_url")
+	AssertError(t, errs, "Test.URI", "Test.URI", "URI", "URI", "uri")
+	AssertError(t, errs, "Test.ZeroAddr", "Test.ZeroAddr", "ZeroAddr", "ZeroAddr", "ip")
+	AssertError(t, errs, "Test.EmptyIP", "Test.EmptyIP", "EmptyIP", "EmptyIP", "ip")
+	AssertError(t, errs, "Test.Addresses[0]", "Test.Addresses[0]", "Addresses[0]", "Addresses[0]", "ipv6")
+}
+
+func TestIPRangeValidations(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		tag      string
+		expected bool
+	}{
+		{"10.1.2.3", "ip_in=10.0.0.0/8", true},
+		{"11.1.2.3", "ip_in=10.0.0.0/8", false},
+		{"192.168.1.1", "ip_in=10.0.0.0/8 192.168.0.0/16", true},
+		{"::ffff:10.1.2.3", "ip_in=10.0.0.0/8", true},
+		{"fd00::1", "ip_in=fd00::/8", true},
+		{"127.0.0.1", "ip_in=127.0.0.1 ::1", true},
+		{"127.0.0.2", "ip_in=127.0.0.1 ::1", false},
+		{"not an ip", "ip_in=10.0.0.0/8", false},
+		{netip.MustParseAddr("10.1.2.3"), "ip_in=10.0.0.0/8", true},
+		{netip.MustParseAddrPort("10.1.2.3:80"), "ip_in=10.0.0.0/8", true},
+		{net.ParseIP("10.1.2.3"), "ip_in=10.0.0.0/8", true},
+		{netip.Addr{}, "ip_in=10.0.0.0/8", false},
+		{"8.8.8.8", "public_ip", true},
+		{"2001:4860:4860::8888", "public_ip", true},
+		{"10.0.0.1", "public_ip", false},
+		{"127.0.0.1", "public_ip", false},
+		{"169.254.0.1", "public_ip", false},
+		{"224.0.0.1", "public_ip", false},
+		{"0.0.0.0", "public_ip", false},
+		{"10.0.0.1", "private_ip", true},
+		{"172.16.0.1", "private_ip", true},
+		{"192.168.0.1", "private_ip", true},
+		{"fd12::1", "private_ip", true},
+		{"8.8.8.8", "private_ip", false},
+		{netip.MustParseAddr("192.168.0.1"), "private_ip", true},
+		{"127.0.0.1", "loopback_ip", true},
+		{"::1", "loopback_ip", true},
+		{"10.0.0.1", "loopback_ip", false},
+		{"224.0.0.1", "multicast_ip", true},
+		{"ff02::1", "multicast_ip", true},
+		{net.IPv4(224, 0, 0, 1), "multicast_ip", true},
+		{"10.0.0.1", "multicast_ip", false},
+	}
+
+	validate := New()
+
+	for i, test := range tests {
+
+		errs := validate.Var(test.value, test.tag)
+
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		}
+	}
+
+	PanicMatches(t, func() { _ = validate.Var("10.0.0.1", "ip_in=10.0.0.0/33") }, `netip.ParsePrefix("10.0.0.0/33"): prefix length out of range`)
+	PanicMatches(t, func() { _ = validate.Var(1, "public_ip") }, "Bad field type int")
+}
+
+func TestSplitTags(t *testing.T) {
+	type Test struct {
+		Recipients string  `validate:"split=0x2C,min=1,dive,email"`
+		Trimmed    string  `validate:"splittrim=;,dive,oneof=a b c"`
+		Words      string  `validate:"splitws,max=3,dive,alpha"`
+		Optional   string  `validate:"omitempty,split=0x2C,dive,email"`
+		Required   string  `validate:"split=0x7C,required"`
+		Ptr        *string `validate:"omitnil,split=0x2C,dive,len=2"`
+	}
+
+	validate := New()
+
+	ptr := "ab,cde"
+
+	tst := Test{
+		Recipients: "a@b.co,nope,c@d.co",
+		Trimmed:    "a ; b ;d",
+		Words:      "one two 3 four",
+		Ptr:        &ptr,
+	}
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertError(t, errs, "Test.Recipients[1]", "Test.Recipients[1]", "Recipients[1]", "Recipients[1]", "email")
+	AssertError(t, errs, "Test.Trimmed[2]", "Test.Trimmed[2]", "Trimmed[2]", "Trimmed[2]", "oneof")
+	AssertError(t, errs, "Test.Words", "Test.Words", "Words", "Words", "max")
+	AssertError(t, errs, "Test.Required", "Test.Required", "Required", "Required", "required")
+	AssertError(t, errs, "Test.Ptr[1]", "Test.Ptr[1]", "Ptr[1]", "Ptr[1]", "len")
+
+	fe := getError(errs, "Test.Recipients[1]", "Test.Recipients[1]")
+	Equal(t, fe.Value(), "nope")
+
+	tst = Test{
+		Recipients: "a@b.co,c@d.co",
+		Trimmed:    " a;b ; c ",
+		Words:      " one  two\tthree ",
+		Required:   "x|y",
+	}
+
+	Equal(t, validate.Struct(tst), nil)
+
+	tst.Recipients = ""
+	tst.Words = "one 2"
+
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Test.Recipients", "Test.Recipients", "Recipients", "Recipients", "min")
+	AssertError(t, errs, "Test.Words[1]", "Test.Words[1]", "Words[1]", "Words[1]", "alpha")
+
+	AssertError(t, validate.Var("a,b,", "split=0x2C,dive,required"), "[2]", "[2]", "[2]", "[2]", "required")
+	Equal(t, validate.Var("a, b", "splittrim=0x2C,dive,alpha"), nil)
+
+	PanicMatches(t, func() { _ = validate.Var("a", "split,dive,alpha") }, "'split' tag on field '' requires a separator eg. split=0x2C")
+	PanicMatches(t, func() { _ = validate.Var(1, "split=0x2C,dive,alpha") }, "'split' tag requires a string, got int")
+
+	// fields which aren't strings are rejected when the struct is cached, even when they'd be skipped
+	type BadSplit struct {
+		Count int `validate:"omitempty,splitws,dive,alpha"`
+	}
+	PanicMatches(t, func() { _ = validate.Struct(BadSplit{}) }, "'splitws' tag on field 'Count' requires a string, got 'int'")
+
+	type BadDiveSplit struct {
+		Counts []int `validate:"dive,split=0x2C,dive,alpha"`
+	}
+	PanicMatches(t, func() { _ = validate.Struct(BadDiveSplit{}) }, "'split' tag on field 'Counts' requires a string, got 'int'")
+	PanicMatches(t, func() { _ = validate.RegisterValidation(splitTag, hasValue) }, fmt.Sprintf(restrictedTagErr, splitTag))
+}
+
+func TestStructLevelOrdering(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+	}
+
+	type User struct {
+		Email    string `validate:"required,email"`
+		Name     string `validate:"warn:max=3"`
+		Address  Address
+		Emails   []string `validate:"dive,email"`
+		Password string
+	}
+
+	var calls []string
+	var failed map[string]bool
+
+	fn := func(sl StructLevel) {
+		calls = append(calls, "struct")
+
+		failed = map[string]bool{}
+		for _, name := range []string{"Email", "Name", "Address", "Address.City", "Emails", "Emails[1]", "Password", "Em"} {
+			failed[name] = sl.(StructLevelFailures).FieldFailed(name)
+		}
+
+		if sl.Current().Interface().(User).Password == "" {
+			sl.ReportError("", "Password", "Password", "password", "")
+		}
+	}
+
+	tst := User{Email: "nope", Name: "joeybloggs", Emails: []string{"a@b.co", "nope"}}
+
+	validate := New()
+	validate.RegisterStructValidation(fn, User{})
+
+	err := validate.Struct(tst)
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 4)
+	AssertError(t, err, "User.Password", "User.Password", "Password", "Password", "password")
+	Equal(t, failed, map[string]bool{
+		"Email": true, "Name": false, "Address": true, "Address.City": true,
+		"Emails": true, "Emails[1]": true, "Password": false, "Em": false,
+	})
+
+	// only run when the fields are valid
+	validate = New(WithStructLevelSkipOnFieldErrors())
+	validate.RegisterStructValidation(fn, User{})
+
+	calls = nil
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 3)
+	Equal(t, len(calls), 0)
+
+	// warnings don't prevent struct level validations from running
+	calls = nil
+	err = validate.Struct(User{Email: "a@b.co", Name: "joeybloggs", Address: Address{City: "here"}})
+	NotEqual(t, err, nil)
+	Equal(t, len(calls), 1)
+	AssertError(t, err, "User.Password", "User.Password", "Password", "Password", "password")
+
+	// run before the fields
+	validate = New(WithStructLevelBeforeFields())
+	validate.RegisterStructValidation(fn, User{})
+
+	err = validate.Struct(tst)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 4)
+	Equal(t, errs[0].Namespace(), "User.Password")
+	Equal(t, errs[1].Namespace(), "User.Email")
+	Equal(t, failed["Email"], false)
+}
+
+func TestNormalize(t *testing.T) {
+	type Address struct {
+		City    string `mod:"trim,title" validate:"required"`
+		Country string `mod:"upper" default:"nz"`
+	}
+
+	type User struct {
+		Email    string            `mod:"trim,lower" validate:"required,email"`
+		Name     string            `mod:"collapse_ws,trim"`
+		Path     string            `mod:"trim=/"`
+		Code     string            `mod:"ltrim=0,rtrim"`
+		Cafe     string            `mod:"nfc"`
+		Limit    int               `default:"10" validate:"max=100"`
+		Timeout  time.Duration     `mod:"default=1m"`
+		Ratio    *float64          `mod:"default=0.5"`
+		Enabled  *bool             `default:"true"`
+		Since    time.Time         `default:"2024-01-02T03:04:05Z"`
+		Tags     []string          `mod:"dive,trim,lower"`
+		Labels   map[string]string `mod:"dive,keys,trim,upper,endkeys,trim"`
+		Address  Address
+		Previous *Address
+		Others   []Address `mod:"dive"`
+		Any      interface{}
+		Skipped  string `mod:"-"`
+		Untagged string
+		private  string
+	}
+
+	validate := New()
+
+	u := User{
+		Email:    "  Joey.Bloggs@Example.COM ",
+		Name:     "\tJoey \n  Bloggs ",
+		Path:     "/a/b/",
+		Code:     "0042  ",
+		Cafe:     "café",
+		Limit:    0,
+		Tags:     []string{" A ", "b"},
+		Labels:   map[string]string{" a ": " x ", "B": "y"},
+		Address:  Address{City: " auckland central "},
+		Others:   []Address{{City: "wellington", Country: "au"}},
+		Any:      &Address{City: " christchurch"},
+		Skipped:  " skipped ",
+		Untagged: " untagged ",
+		private:  " private ",
+	}
+
+	err := validate.Normalize(context.Background(), &u)
+	Equal(t, err, nil)
+
+	Equal(t, u.Email, "joey.bloggs@example.com")
+	Equal(t, u.Name, "Joey Bloggs")
+	Equal(t, u.Path, "a/b")
+	Equal(t, u.Code, "42")
+	Equal(t, u.Cafe, "caf\u00e9")
+	Equal(t, u.Limit, 10)
+	Equal(t, u.Timeout, time.Minute)
+	NotEqual(t, u.Ratio, nil)
+	Equal(t, *u.Ratio, 0.5)
+	NotEqual(t, u.Enabled, nil)
+	Equal(t, *u.Enabled, true)
+	Equal(t, u.Since, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	Equal(t, u.Tags, []string{"a", "b"})
+	Equal(t, u.Labels, map[string]string{"A": "x", "B": "y"})
+	Equal(t, u.Address, Address{City: "Auckland Central", Country: "NZ"})
+	Equal(t, u.Previous, nil)
+	Equal(t, u.Others, []Address{{City: "Wellington", Country: "AU"}})
+	Equal(t, u.Any, &Address{City: "Christchurch", Country: "NZ"})
+	Equal(t, u.Skipped, " skipped ")
+	Equal(t, u.Untagged, " untagged ")
+	Equal(t, u.private, " private ")
+
+	// values which aren't zero keep their value
+	u.Limit = 20
+	u.Since = time.Time{}.Add(time.Hour)
+
+	err = validate.Normalize(context.Background(), &u)
+	Equal(t, err, nil)
+	Equal(t, u.Limit, 20)
+	Equal(t, u.Since, time.Time{}.Add(time.Hour))
+
+	// custom modifiers
+	err = validate.RegisterModifier("digits", func(ctx context.Context, fl FieldLevel) {
+		fl.Field().SetString(strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, fl.Field().String()))
+	})
+	Equal(t, err, nil)
+
+	type Phone struct {
+		Number string `mod:"digits" validate:"required,numeric,len=10"`
+	}
+
+	p := Phone{Number: "(021) 555-0123"}
+
+	err = validate.NormalizeAndValidate(context.Background(), &p)
+	Equal(t, err, nil)
+	Equal(t, p.Number, "0215550123")
+
+	p = Phone{Number: "(021) 555"}
+
+	err = validate.NormalizeAndValidate(context.Background(), &p)
+	NotEqual(t, err, nil)
+	AssertError(t, err, "Phone.Number", "Phone.Number", "Number", "Number", "len")
+
+	err = validate.NormalizeAndValidate(context.Background(), &User{Email: " NOPE "})
+	NotEqual(t, err, nil)
+	AssertError(t, err, "User.Email", "User.Email", "Email", "Email", "email")
+	AssertError(t, err, "User.Address.City", "User.Address.City", "City", "City", "required")
+
+	// invalid values
+	err = validate.Normalize(context.Background(), u)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil validator.User)")
+
+	err = validate.Normalize(context.Background(), (*User)(nil))
+	NotEqual(t, err, nil)
+
+	err = validate.Normalize(context.Background(), &time.Time{})
+	NotEqual(t, err, nil)
+
+	err = validate.RegisterModifier("", func(ctx context.Context, fl FieldLevel) {})
+	NotEqual(t, err, nil)
+
+	err = validate.RegisterModifier("nil", nil)
+	NotEqual(t, err, nil)
+
+	PanicMatches(t, func() { _ = validate.RegisterModifier("dive", func(ctx context.Context, fl FieldLevel) {}) }, fmt.Sprintf(restrictedTagErr, "dive"))
+
+	type Undefined struct {
+		Name string `mod:"trim,nope"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Normalize(context.Background(), &Undefined{}) }, "Undefined modifier 'nope' on field 'Name'")
+
+	type BadType struct {
+		Count int `mod:"trim"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Normalize(context.Background(), &BadType{}) }, "Bad field type int")
+
+	type BadDive struct {
+		Name string `mod:"dive,trim"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Normalize(context.Background(), &BadDive{}) }, "dive error! can't dive on a non slice or map")
+
+	type Validation struct {
+		Name string `mod:"trim,omitempty"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Normalize(context.Background(), &Validation{}) }, "Undefined modifier 'omitempty' on field 'Name'")
+
+	// keys modified to the same key don't overwrite each other's elements
+	type Collision struct {
+		Labels map[string]int `mod:"dive,keys,trim,lower,endkeys"`
+	}
+
+	type Collisions struct {
+		Items []Collision `mod:"dive"`
+	}
+
+	c := Collisions{Items: []Collision{{Labels: map[string]int{"a": 1}}, {Labels: map[string]int{" A ": 1, "a": 2, "b": 3}}}}
+
+	err = validate.Normalize(context.Background(), &c)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: the keys ' A ' and 'a' of 'Collisions.Items[1].Labels' are both modified to 'a'")
+
+	var collision *KeyCollisionError
+	Equal(t, errors.As(err, &collision), true)
+	Equal(t, collision.Namespace, "Collisions.Items[1].Labels")
+	Equal(t, collision.Keys, []interface{}{" A ", "a"})
+	Equal(t, collision.Key, "a")
+}
+
+func TestNormalizeMapRules(t *testing.T) {
+	type Address struct {
+		City string `mod:"upper"`
+	}
+
+	type Item struct {
+		SKU string
+	}
+
+	type Order struct {
+		Email   string `validate:"required"`
+		Note    string `mod:"trim" validate:"-"`
+		Address Address
+		Items   []Item
+		Meta    map[string]string
+	}
+
+	validate := New()
+	validate.RegisterStructValidationMapRules(map[string]string{
+		"Email":        "trim,lower,required,email",
+		"Address.City": "trim,title",
+		"Items[*].SKU": "trim,upper,required",
+		"Meta":         "dive,keys,lower,endkeys,trim,max=3",
+	}, Order{})
+
+	o := Order{
+		Email:   " Joey@Example.COM ",
+		Note:    " note ",
+		Address: Address{City: " auckland"},
+		Items:   []Item{{SKU: " ab1 "}},
+		Meta:    map[string]string{"A": " x "},
+	}
+
+	err := validate.NormalizeAndValidate(context.Background(), &o)
+	Equal(t, err, nil)
+	Equal(t, o.Email, "joey@example.com")
+	Equal(t, o.Note, "note")
+	Equal(t, o.Address.City, "Auckland")
+	Equal(t, o.Items, []Item{{SKU: "AB1"}})
+	Equal(t, o.Meta, map[string]string{"a": "x"})
+
+	// the validations of the rules are kept apart from their modifiers
+	err = validate.NormalizeAndValidate(context.Background(), &Order{Items: []Item{{SKU: " "}}, Meta: map[string]string{"a": "long"}})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "Order.Email", "Order.Email", "Email", "Email", "required")
+	AssertError(t, errs, "Order.Items[0].SKU", "Order.Items[0].SKU", "SKU", "SKU", "required")
+	AssertError(t, errs, "Order.Meta[a]", "Order.Meta[a]", "Meta[a]", "Meta[a]", "max")
+
+	type User struct {
+		Name  string   `mod:"upper"`
+		Tags  []string `validate:"dive,required"`
+		Other Address
+	}
+
+	validate = New()
+	Rules[User](validate).
+		Field(func(u *User) interface{} { return &u.Name }, "trim,required").
+		Field(func(u *User) interface{} { return &u.Tags }, "dive,trim,lower").
+		Field(func(u *User) interface{} { return &u.Other.City }, "lower")
+
+	u := User{Name: " Joey ", Tags: []string{" A ", ""}, Other: Address{City: "Here"}}
+
+	err = validate.Normalize(context.Background(), &u)
+	Equal(t, err, nil)
+	Equal(t, u, User{Name: "Joey", Tags: []string{"a", ""}, Other: Address{City: "here"}})
+
+	// the modifiers of the rules replace those of the 'mod' tag, and their validations those of the struct tag
+	Equal(t, validate.Struct(u), nil)
+
+	err = validate.Struct(User{})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "required")
+}
+
+func TestClockAndTimeValidations(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	validate := New(WithClock(func() time.Time { return now }))
+
+	tests := []struct {
+		value    interface{}
+		tag      string
+		expected bool
+	}{
+		{now.Add(-time.Second), "before=now", true},
+		{now, "before=now", false},
+		{now.Add(30 * time.Minute), "before=now+1h", true},
+		{now.Add(2 * time.Hour), "before=now+1h", false},
+		{now, "before=2024-06-16", true},
+		{now, "before=2024-06-15T11:00:00Z", false},
+		{now, "before=2024-06-15T13:00:00+02:00", false},
+		{now.Add(time.Second), "after=now", true},
+		{now, "after=now", false},
+		{now.Add(-30 * time.Minute), "after=now-1h", true},
+		{now, "after=2020-01-01", true},
+		{time.Time{}, "after=2020-01-01", false},
+		{now.Add(23 * time.Hour), "within=24h", true},
+		{now.Add(-23 * time.Hour), "within=24h", true},
+		{now.Add(-25 * time.Hour), "within=24h", false},
+		{now.Add(-29 * 24 * time.Hour), "not_older_than=30d", true},
+		{now.Add(-31 * 24 * time.Hour), "not_older_than=30d", false},
+		{now.Add(-13 * 24 * time.Hour), "not_older_than=2w", true},
+		{now.Add(365 * 24 * time.Hour), "not_older_than=2w", true},
+		{"2024-06-15T11:00:00Z", "before=now", true},
+		{"2024-06-15T13:00:00Z", "before=now", false},
+		{"2024-06-14", "after=2024-06-13", true},
+		{"2024-06-14", "within=12h", false},
+		{"2024-06-15T00:00:00Z", "within=12h", true},
+		{"", "before=now", false},
+		{"yesterday", "within=48h", false},
+		{"15/06/2024 11:00", "before=now 02/01/2006 15:04", true},
+		{"2024-06-15 13:00:00", "before=now 2006-01-02 15:04:05", false},
+		{"2024-06-15T11:00:00Z", "before=now 02/01/2006 15:04", false},
+		{"14/06/2024", "after=13/06/2024 02/01/2006", true},
+		{"14/06/2024", "after=2024-06-14 02/01/2006", false},
+		{now, "before=16/06/2024 02/01/2006", true},
+		{"14/06/2024", "within=48h 02/01/2006", true},
+		{"2024-06-14", "within=48h 02/01/2006", false},
+		{"2024-06-01 12:00", "not_older_than=30d 2006-01-02 15:04", true},
+		{"2024-05-01 12:00", "not_older_than=30d 2006-01-02 15:04", false},
+		{now.Add(time.Second), "gt", true},
+		{now, "gt", false},
+		{now, "gte", true},
+		{now.Add(-time.Second), "lt", true},
+		{now, "lte", true},
+		{now.Add(time.Second), "lte", false},
+	}
+
+	for i, test := range tests {
+
+		errs := validate.Var(test.value, test.tag)
+
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		}
+	}
+
+	// the clock can be overridden per call
+	ctx := ContextWithClock(context.Background(), func() time.Time { return now.Add(48 * time.Hour) })
+
+	errs := validate.VarCtx(ctx, now.Add(24*time.Hour), "before=now")
+	Equal(t, errs, nil)
+
+	errs = validate.VarCtx(ctx, now.Add(24*time.Hour), "within=12h")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "within")
+
+	errs = validate.Var(now.Add(24*time.Hour), "before=now")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "before")
+
+	type Event struct {
+		Start time.Time `validate:"after=now"`
+		Date  string    `validate:"datetime=2006-01-02,not_older_than=7d"`
+	}
+
+	errs = validate.Struct(Event{Start: now.Add(time.Hour), Date: "2024-06-10"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Event{Start: now.Add(-time.Hour), Date: "2024-06-01"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Event.Start", "Event.Start", "Start", "Start", "after")
+	AssertError(t, errs, "Event.Date", "Event.Date", "Date", "Date", "not_older_than")
+
+	// without a clock time.Now is used
+	errs = New().Var(time.Now().Add(-time.Minute), "within=1h")
+	Equal(t, errs, nil)
+
+	PanicMatches(t, func() { _ = validate.Var(now, "before=tomorrow") }, "Bad time param 'tomorrow'")
+	PanicMatches(t, func() { _ = validate.Var(now, "within=1y") }, `time: unknown unit "y" in duration "1y"`)
+	PanicMatches(t, func() { _ = validate.Var(1, "before=now") }, "Bad field type int")
+}
+
+// asyncStore is an in-memory stand-in for a datastore used by asynchronous validations.
+type asyncStore struct {
+	mu      sync.Mutex
+	taken   map[string]bool
+	delay   time.Duration
+	running int
+	maxRun  int
+	calls   int
+}
+
+func (s *asyncStore) exists(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	s.calls++
+	s.running++
+	if s.running > s.maxRun {
+		s.maxRun = s.running
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.running--
+		s.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.taken[key], nil
+}
+
+func TestAsyncValidations(t *testing.T) {
+	store := &asyncStore{taken: map[string]bool{"joeybloggs": true, "taken@example.com": true, "slow": true}, delay: 20 * time.Millisecond}
+
+	unique := func(ctx context.Context, fl FieldLevel) error {
+		if fl.Field().String() == "slow" {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		taken, err := store.exists(ctx, fl.Field().String())
+		if err != nil {
+			return err
+		}
+		if taken {
+			return NewFuncError("already taken", "value", fl.Field().String())
+		}
+		return nil
+	}
+
+	validate := New(WithAsyncWorkers(2))
+	Equal(t, validate.RegisterAsyncValidation("unique", unique, 100*time.Millisecond), nil)
+
+	type User struct {
+		Username string   `validate:"required,unique,max=10"`
+		Email    string   `validate:"required,email,unique"`
+		Aliases  []string `validate:"dive,unique"`
+		Age      int      `validate:"gte=18"`
+		Nickname string   `validate:"omitempty,unique|eq=admin"`
+	}
+
+	user := User{
+		Username: "joeybloggs",
+		Email:    "taken@example.com",
+		Aliases:  []string{"joey", "joeybloggs", "bloggs", "joeybloggs"},
+		Age:      10,
+	}
+
+	err := validate.StructCtx(context.Background(), user)
+	NotEqual(t, err, nil)
+
+	// 6 validations run using 2 workers
+	Equal(t, store.calls, 6)
+	Equal(t, store.maxRun, 2)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	Equal(t, errs[0].Namespace(), "User.Username")
+	Equal(t, errs[1].Namespace(), "User.Email")
+	Equal(t, errs[2].Namespace(), "User.Aliases[1]")
+	Equal(t, errs[3].Namespace(), "User.Aliases[3]")
+	Equal(t, errs[4].Namespace(), "User.Age")
+	Equal(t, errs[0].Tag(), "unique")
+	Equal(t, errs[0].(FieldErrorDetails).Details(), map[string]interface{}{"value": "joeybloggs"})
+	Equal(t, errs[4].Tag(), "gte")
+
+	// fields failing synchronous validations skip their asynchronous ones
+	store.calls = 0
+
+	err = validate.Struct(User{Username: "", Email: "nope", Nickname: "joeybloggs"})
+	NotEqual(t, err, nil)
+	Equal(t, store.calls, 1)
+	AssertError(t, err, "User.Username", "User.Username", "Username", "Username", "required")
+	AssertError(t, err, "User.Email", "User.Email", "Email", "Email", "email")
+	AssertError(t, err, "User.Nickname", "User.Nickname", "Nickname", "Nickname", "unique|eq=admin")
+
+	err = validate.Struct(User{Username: "new", Email: "new@example.com", Age: 20})
+	Equal(t, err, nil)
+
+	// timeouts
+	err = validate.Var("slow", "unique")
+	NotEqual(t, err, nil)
+	AssertError(t, err, "", "", "", "", "unique")
+
+	var fe FieldError
+	Equal(t, errors.As(err, &fe), true)
+	Equal(t, errors.Is(fe, context.DeadlineExceeded), true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = validate.VarCtx(ctx, "available", "unique")
+	NotEqual(t, err, nil)
+	Equal(t, errors.As(err, &fe), true)
+	Equal(t, errors.Is(fe, context.Canceled), true)
+
+	// warnings and the allerrors tag
+	type Account struct {
+		Name string `validate:"allerrors,unique,warn:unique,max=3"`
+	}
+
+	res, err := validate.StructResult(Account{Name: "joeybloggs"})
+	Equal(t, err, nil)
+	Equal(t, len(res.Errors), 2)
+	Equal(t, res.Errors[0].Tag(), "unique")
+	Equal(t, res.Errors[1].Tag(), "max")
+	Equal(t, len(res.Warnings), 1)
+
+	// panics are raised by the caller
+	Equal(t, validate.RegisterAsyncValidation("boom", func(ctx context.Context, fl FieldLevel) error { panic("boom") }, 0), nil)
+	PanicMatches(t, func() { _ = validate.Var("x", "boom") }, "boom")
+
+	// registering a synchronous validation with the tag replaces it
+	validate = New()
+	Equal(t, validate.RegisterAsyncValidation("boom", func(ctx context.Context, fl FieldLevel) error { panic("boom") }, 0), nil)
+	Equal(t, validate.RegisterValidation("boom", func(fl FieldLevel) bool { return false }), nil)
+	NotEqual(t, validate.Var("x", "boom"), nil)
+
+	NotEqual(t, validate.RegisterAsyncValidation("nil", nil, 0), nil)
+	NotEqual(t, validate.RegisterAsyncValidation("", unique, 0), nil)
+}
+
+// badLookup is a LookupProvider which never returns any results.
+type badLookup struct{}
+
+func (badLookup) Lookup(ctx context.Context, req LookupRequest) ([]bool, error) {
+	return nil, nil
+}
+
+func TestLookupProvider(t *testing.T) {
+	lookup := NewMemoryLookup()
+	lookup.Add("users", 1, map[string]interface{}{"email": "joey@example.com"})
+	lookup.Add("users", 2, map[string]interface{}{"email": "bloggs@example.com"})
+
+	for i := 1; i <= 500; i++ {
+		lookup.Add("tags", i, nil)
+	}
+
+	validate := New(WithLookupProvider(lookup))
+
+	type Post struct {
+		AuthorID int   `validate:"exists=users.id"`
+		TagIDs   []int `validate:"required,dive,exists=tags.id"`
+	}
+
+	tagIDs := make([]int, 500)
+	for i := range tagIDs {
+		tagIDs[i] = i + 1
+	}
+
+	err := validate.Struct(Post{AuthorID: 1, TagIDs: tagIDs})
+	Equal(t, err, nil)
+
+	// one lookup for each source
+	Equal(t, lookup.Lookups(), 2)
+
+	tagIDs[10] = 501
+	tagIDs[20] = 0
+
+	err = validate.Struct(Post{AuthorID: 3, TagIDs: tagIDs})
+	NotEqual(t, err, nil)
+	Equal(t, lookup.Lookups(), 4)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "Post.AuthorID", "Post.AuthorID", "AuthorID", "AuthorID", "exists")
+	AssertError(t, errs, "Post.TagIDs[10]", "Post.TagIDs[10]", "TagIDs[10]", "TagIDs[10]", "exists")
+	AssertError(t, errs, "Post.TagIDs[20]", "Post.TagIDs[20]", "TagIDs[20]", "TagIDs[20]", "exists")
+	Equal(t, errs[0].Param(), "users.id")
+	Equal(t, errs[0].(FieldErrorDetails).Details(), map[string]interface{}{"source": "users.id"})
+
+	// uniqueness, excluding the record being updated
+	type User struct {
+		ID    int
+		Email string `validate:"required,email,unique_in=users.email ID"`
+	}
+
+	type Signup struct {
+		Email string `validate:"unique_in=users.email"`
+	}
+
+	err = validate.Struct(User{Email: "new@example.com"})
+	Equal(t, err, nil)
+
+	err = validate.Struct(User{Email: "joey@example.com"})
+	NotEqual(t, err, nil)
+	AssertError(t, err, "User.Email", "User.Email", "Email", "Email", "unique_in")
+
+	err = validate.Struct(User{ID: 1, Email: "joey@example.com"})
+	Equal(t, err, nil)
+
+	err = validate.Struct(User{ID: 2, Email: "joey@example.com"})
+	NotEqual(t, err, nil)
+	AssertError(t, err, "User.Email", "User.Email", "Email", "Email", "unique_in")
+
+	err = validate.Struct(Signup{Email: "bloggs@example.com"})
+	NotEqual(t, err, nil)
+	AssertError(t, err, "Signup.Email", "Signup.Email", "Email", "Email", "unique_in")
+
+	err = validate.Var("new@example.com", "unique_in=users.email")
+	Equal(t, err, nil)
+
+	// excluded records of different types aren't looked up together
+	type Import struct {
+		ID    int
+		Ref   string
+		Email string `validate:"unique_in=users.email ID"`
+		Alias string `validate:"unique_in=users.email Ref"`
+	}
+
+	lookups := lookup.Lookups()
+
+	err = validate.Struct(Import{ID: 1, Ref: "1", Email: "joey@example.com", Alias: "joey@example.com"})
+	NotEqual(t, err, nil)
+	Equal(t, lookup.Lookups(), lookups+2)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Import.Alias", "Import.Alias", "Alias", "Alias", "unique_in")
+
+	// lookups aren't made for fields which already failed
+	lookups = lookup.Lookups()
+
+	err = validate.Struct(User{Email: "nope"})
+	NotEqual(t, err, nil)
+	AssertError(t, err, "User.Email", "User.Email", "Email", "Email", "email")
+	Equal(t, lookup.Lookups(), lookups)
+
+	// errors of the provider are returned by the call rather than failing the validation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = validate.VarCtx(ctx, 1, "exists=users.id")
+	Equal(t, err, context.Canceled)
+
+	err = validate.StructCtx(ctx, Post{AuthorID: 0, TagIDs: []int{1}})
+	Equal(t, err, context.Canceled)
+
+	err = validate.Var(1, "exists=users")
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: invalid lookup source 'users', must be a table and column eg. users.id")
+
+	var fe FieldError
+	Equal(t, errors.As(err, &fe), false)
+
+	// including within 'or' tags, where they're run synchronously
+	err = validate.Var(1, "exists=users|eq=1")
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: invalid lookup source 'users', must be a table and column eg. users.id")
+
+	res, err := validate.StructResultCtx(ctx, Post{AuthorID: 1, TagIDs: []int{1}})
+	Equal(t, err, context.Canceled)
+	Equal(t, res, nil)
+
+	// providers must return a result for each value
+	validate = New(WithLookupProvider(badLookup{}))
+
+	err = validate.Struct(Post{AuthorID: 1, TagIDs: []int{1, 2}})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: LookupProvider returned 0 results for 1 values")
+
+	PanicMatches(t, func() { _ = validate.Var(1, "exists") }, "Bad param '' for 'exists', must be a source optionally followed by a field eg. users.email ID")
+
+	type Missing struct {
+		Email string `validate:"unique_in=users.email ID"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(Missing{}) }, "Field 'ID' not found for 'unique_in'")
+
+	// the tags are only available with a provider
+	PanicMatches(t, func() { _ = New().Var(1, "exists=users.id") }, "Undefined validation function 'exists' on field ''")
+}